@@ -0,0 +1,293 @@
+package gxclient_go
+
+import (
+	"github.com/pkg/errors"
+	"gxclient-go/api/database"
+	"gxclient-go/types"
+	"strings"
+)
+
+// proxyToSelfAccount is the voting account of accounts voting for themselves
+const proxyToSelfAccount = "1.2.5"
+
+// RegisterAccount registers the account name paid by the client account, with ownerPub, activePub and memoPub
+// as its owner, active and memo keys. Empty ownerPub and memoPub default to activePub, an empty referrer
+// to the client account. The name is checked against the account name rules of the chain.
+func (client *Client) RegisterAccount(name, ownerPub, activePub, memoPub, referrer, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	config, err := client.Database.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain config")
+	}
+	if err := validateAccountName(name, config); err != nil {
+		return nil, err
+	}
+
+	if ownerPub == "" {
+		ownerPub = activePub
+	}
+	if memoPub == "" {
+		memoPub = activePub
+	}
+	keys := map[string]*types.PublicKey{}
+	for role, pub := range map[string]string{"owner": ownerPub, "active": activePub, "memo": memoPub} {
+		key, err := types.NewPublicKeyFromString(pub)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s key: %v", role, pub)
+		}
+		keys[role] = key
+	}
+
+	if _, err := client.Database.GetAccount(name); err == nil {
+		return nil, errors.Errorf("account %s already exists", name)
+	}
+
+	referrerID := client.account.ID.String()
+	if referrer != "" {
+		referrerAccount, err := client.Database.GetAccount(referrer)
+		if err != nil {
+			return nil, err
+		}
+		referrerID = referrerAccount.ID.String()
+	}
+
+	options := types.AccountOptions{
+		MemoKey:       *keys["memo"],
+		VotingAccount: *types.NewGrapheneID(proxyToSelfAccount),
+		Votes:         types.Votes{},
+		Extensions:    types.Extensions{},
+	}
+	op := types.NewAccountCreateOperation(*types.NewGrapheneID(client.account.ID.String()), *types.NewGrapheneID(referrerID), 0,
+		types.NewKeyAuthority(keys["owner"]), types.NewKeyAuthority(keys["active"]), name, options)
+
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// validateAccountName checks name against the rules of the chain: its length is within the bounds of config,
+// and each of its labels separated by dots starts with a lower case letter, ends with a lower case letter or
+// a digit and has only lower case letters, digits and dashes
+func validateAccountName(name string, config *database.Config) error {
+	if len(name) < int(config.GrapheneMinAccountNameLength) || len(name) > int(config.GrapheneMaxAccountNameLength) {
+		return errors.Errorf("account name %s must have %d to %d characters", name,
+			config.GrapheneMinAccountNameLength, config.GrapheneMaxAccountNameLength)
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return errors.Errorf("account name %s has an empty label", name)
+		}
+		if label[0] < 'a' || label[0] > 'z' {
+			return errors.Errorf("account name %s must start each label with a lower case letter", name)
+		}
+		last := label[len(label)-1]
+		if !(last >= 'a' && last <= 'z' || last >= '0' && last <= '9') {
+			return errors.Errorf("account name %s must end each label with a lower case letter or a digit", name)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return errors.Errorf("account name %s has invalid character %q", name, c)
+			}
+		}
+	}
+	return nil
+}
+
+// AuthorityRole is the authority of an account changed by UpdateAccountAuthority
+type AuthorityRole int
+
+const (
+	// OwnerRole is the owner authority, it can change every authority of the account
+	OwnerRole AuthorityRole = iota
+	// ActiveRole is the active authority, it signs the operations of the account
+	ActiveRole
+)
+
+// UpdateAccountAuthority replaces the authority of role of the client account by authority.
+// The update is refused when the weight threshold of authority cannot be reached by its auths,
+// accounts of the account auths that do not exist are not counted.
+// Changing the owner authority requires the signer of the client to satisfy the current owner authority.
+func (client *Client) UpdateAccountAuthority(role AuthorityRole, authority *types.Authority, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	if err := client.checkAuthority(authority); err != nil {
+		return nil, err
+	}
+
+	account := types.MustParseObjectID(client.account.ID.String())
+	var op *types.AccountUpdateOperation
+	switch role {
+	case OwnerRole:
+		op = types.NewAccountUpdateOperation(account, authority, nil, nil, types.AssetAmount{})
+	case ActiveRole:
+		op = types.NewAccountUpdateOperation(account, nil, authority, nil, types.AssetAmount{})
+	default:
+		return nil, errors.Errorf("unknown authority role %d", role)
+	}
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// RotateKeys replaces the owner and active authorities of the client account by authorities of the single keys
+// newOwner and newActive, and its memo key by newMemo. Empty keys are left unchanged.
+func (client *Client) RotateKeys(newOwner, newActive, newMemo, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	if newOwner == "" && newActive == "" && newMemo == "" {
+		return nil, errors.New("no key to rotate")
+	}
+
+	account := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewAccountUpdateOperation(account, nil, nil, nil, types.AssetAmount{})
+
+	if newOwner != "" {
+		pub, err := types.NewPublicKeyFromString(newOwner)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid owner key: %v", newOwner)
+		}
+		owner := types.NewKeyAuthority(pub)
+		op.Owner = &owner
+	}
+	if newActive != "" {
+		pub, err := types.NewPublicKeyFromString(newActive)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid active key: %v", newActive)
+		}
+		active := types.NewKeyAuthority(pub)
+		op.Active = &active
+	}
+	if newMemo != "" {
+		pub, err := types.NewPublicKeyFromString(newMemo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid memo key: %v", newMemo)
+		}
+		current, err := client.currentAccount()
+		if err != nil {
+			return nil, err
+		}
+		options := current.Options
+		options.MemoKey = *pub
+		op.NewOptions = &options
+	}
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// AddAuthorityKey adds pub with weight to the authority of role of the client account, or changes its weight
+func (client *Client) AddAuthorityKey(role AuthorityRole, pub string, weight uint16, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	key, err := types.NewPublicKeyFromString(pub)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key: %v", pub)
+	}
+	if weight == 0 {
+		return nil, errors.New("weight must be positive")
+	}
+	return client.changeAuthority(role, func(authority *types.Authority) error {
+		authority.SetKey(key, types.UInt16(weight))
+		return nil
+	}, feeSymbol, broadcast)
+}
+
+// RemoveAuthorityKey removes pub from the authority of role of the client account
+func (client *Client) RemoveAuthorityKey(role AuthorityRole, pub string, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	key, err := types.NewPublicKeyFromString(pub)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key: %v", pub)
+	}
+	return client.changeAuthority(role, func(authority *types.Authority) error {
+		if !authority.RemoveKey(key) {
+			return errors.Errorf("key %s is not in the authority", pub)
+		}
+		return nil
+	}, feeSymbol, broadcast)
+}
+
+// AddAuthorityAccount adds the account named account with weight to the authority of role of the client account,
+// or changes its weight
+func (client *Client) AddAuthorityAccount(role AuthorityRole, account string, weight uint16, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	if weight == 0 {
+		return nil, errors.New("weight must be positive")
+	}
+	auth, err := client.Database.GetAccount(account)
+	if err != nil {
+		return nil, err
+	}
+	return client.changeAuthority(role, func(authority *types.Authority) error {
+		authority.SetAccount(*types.NewGrapheneID(auth.ID.String()), types.UInt16(weight))
+		return nil
+	}, feeSymbol, broadcast)
+}
+
+// RemoveAuthorityAccount removes the account named account from the authority of role of the client account
+func (client *Client) RemoveAuthorityAccount(role AuthorityRole, account string, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	auth, err := client.Database.GetAccount(account)
+	if err != nil {
+		return nil, err
+	}
+	return client.changeAuthority(role, func(authority *types.Authority) error {
+		if !authority.RemoveAccount(*types.NewGrapheneID(auth.ID.String())) {
+			return errors.Errorf("account %s is not in the authority", account)
+		}
+		return nil
+	}, feeSymbol, broadcast)
+}
+
+// changeAuthority applies change to a copy of the current authority of role and updates the account with it
+func (client *Client) changeAuthority(role AuthorityRole, change func(authority *types.Authority) error, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	current, err := client.currentAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	var authority *types.Authority
+	switch role {
+	case OwnerRole:
+		authority = current.Owner.Clone()
+	case ActiveRole:
+		authority = current.Active.Clone()
+	default:
+		return nil, errors.Errorf("unknown authority role %d", role)
+	}
+	if err := change(authority); err != nil {
+		return nil, err
+	}
+	return client.UpdateAccountAuthority(role, authority, feeSymbol, broadcast)
+}
+
+// currentAccount fetches the client account, the account loaded with the client may be outdated
+func (client *Client) currentAccount() (*types.Account, error) {
+	accounts, err := client.Database.GetAccountsByIds(client.account.ID.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get account")
+	}
+	if len(accounts) == 0 || accounts[0] == nil {
+		return nil, errors.Errorf("account %s not found", client.account.ID.String())
+	}
+	return accounts[0], nil
+}
+
+// checkAuthority checks that the weight threshold of authority can be reached by its auths,
+// not counting the accounts that do not exist
+func (client *Client) checkAuthority(authority *types.Authority) error {
+	if authority == nil {
+		return errors.New("authority is nil")
+	}
+	if err := authority.Validate(); err != nil {
+		return err
+	}
+	if len(authority.AccountAuths) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(authority.AccountAuths))
+	for id := range authority.AccountAuths {
+		ids = append(ids, id.String())
+	}
+	accounts, err := client.Database.GetAccountsByIds(ids...)
+	if err != nil {
+		return errors.Wrap(err, "failed to get accounts of account auths")
+	}
+
+	reachable := authority.Clone()
+	for i, id := range ids {
+		if i >= len(accounts) || accounts[i] == nil {
+			reachable.RemoveAccount(*types.NewGrapheneID(id))
+		}
+	}
+	if err := reachable.Validate(); err != nil {
+		return errors.Wrap(err, "accounts of account auths do not exist")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package broadcast
 
 import (
+	"context"
 	"gxclient-go/rpc"
 	"gxclient-go/types"
 	"reflect"
@@ -9,10 +10,18 @@ import (
 type API struct {
 	caller rpc.Caller
 	id     rpc.APIID
+	ctx    context.Context
 }
 
 func NewAPI(id rpc.APIID, caller rpc.Caller) *API {
-	return &API{id: id, caller: caller}
+	return &API{id: id, caller: caller, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the API whose calls are bound to ctx
+func (api *API) WithContext(ctx context.Context) *API {
+	copied := *api
+	copied.ctx = ctx
+	return &copied
 }
 
 func typeof(v interface{}) string {
@@ -24,7 +33,7 @@ func (api *API) call(method string, args []interface{}, reply interface{}) error
 	if err != nil {
 		return err
 	}
-	return api.caller.Call(api.id, method, args, reply)
+	return rpc.CallContext(api.ctx, api.caller, api.id, method, args, reply)
 }
 
 // BroadcastTransaction broadcast a transaction to the network.
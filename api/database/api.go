@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
@@ -8,13 +9,24 @@ import (
 	"gxclient-go/types"
 )
 
+// ErrNotFound is the cause of the errors of lookups of an object that does not exist
+var ErrNotFound = errors.New("not found")
+
 type API struct {
 	caller rpc.Caller
 	id     rpc.APIID
+	ctx    context.Context
 }
 
 func NewAPI(id rpc.APIID, caller rpc.Caller) *API {
-	return &API{id: id, caller: caller}
+	return &API{id: id, caller: caller, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the API whose calls are bound to ctx
+func (api *API) WithContext(ctx context.Context) *API {
+	copied := *api
+	copied.ctx = ctx
+	return &copied
 }
 
 func (api *API) call(method string, args []interface{}, reply interface{}) error {
@@ -22,11 +34,22 @@ func (api *API) call(method string, args []interface{}, reply interface{}) error
 	if err != nil {
 		return err
 	}
-	return api.caller.Call(api.id, method, args, reply)
+	return rpc.CallContext(api.ctx, api.caller, api.id, method, args, reply)
 }
 
-func (api *API) setCallback(method string, callback func(raw json.RawMessage)) error {
-	return api.caller.SetCallback(api.id, method, callback)
+// setCallback returns a function unsetting the callback on the transports able to, see rpc.CallbackUnsetter
+func (api *API) setCallback(method string, callback func(raw json.RawMessage)) (func(), error) {
+	if unsetter, ok := api.caller.(rpc.CallbackUnsetter); ok {
+		id, err := unsetter.SetCallbackID(api.id, method, callback)
+		if err != nil {
+			return nil, err
+		}
+		return func() { unsetter.UnsetCallback(id) }, nil
+	}
+	if err := api.caller.SetCallback(api.id, method, callback); err != nil {
+		return nil, err
+	}
+	return func() {}, nil
 }
 
 // GET ChainId of entry point
@@ -36,6 +59,13 @@ func (api *API) GetChainId() (string, error) {
 	return resp, err
 }
 
+// GetConfig returns the compile time constants of the chain, e.g. the account name length rules
+func (api *API) GetConfig() (*Config, error) {
+	var resp Config
+	err := api.call("get_config", rpc.EmptyParams, &resp)
+	return &resp, err
+}
+
 // Gets dynamic global properties of current blockchain
 func (api *API) GetDynamicGlobalProperties() (*DynamicGlobalProperties, error) {
 	var resp DynamicGlobalProperties
@@ -50,6 +80,86 @@ func (api *API) GetBlock(blockNum uint32) (*Block, error) {
 	return &resp, err
 }
 
+// maximum number of calls sent in one batch
+const maxBatchSize = 100
+
+// GetBlocks returns the blocks from `from` to `to` inclusive, in one round trip per
+// hundred blocks when the transport supports batching
+func (api *API) GetBlocks(from, to uint32) ([]*Block, error) {
+	if from > to {
+		return nil, errors.Errorf("invalid block range %d-%d", from, to)
+	}
+
+	blocks := make([]*Block, 0, to-from+1)
+	batchCaller, ok := api.caller.(rpc.BatchCaller)
+	if !ok {
+		for num := from; ; num++ {
+			block, err := api.GetBlock(num)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, block)
+			if num == to {
+				return blocks, nil
+			}
+		}
+	}
+
+	for start := uint64(from); start <= uint64(to); start += maxBatchSize {
+		end := start + maxBatchSize - 1
+		if end > uint64(to) {
+			end = uint64(to)
+		}
+
+		items := make([]rpc.BatchItem, 0, end-start+1)
+		for num := start; num <= end; num++ {
+			items = append(items, rpc.BatchItem{
+				API:    api.id,
+				Method: "get_block",
+				Args:   []interface{}{uint32(num)},
+				Reply:  &Block{},
+			})
+		}
+
+		if err := batchCaller.CallBatchContext(api.ctx, items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.Error != nil {
+				return nil, errors.Wrapf(item.Error, "failed to get block %v", item.Args[0])
+			}
+			blocks = append(blocks, item.Reply.(*Block))
+		}
+	}
+	return blocks, nil
+}
+
+// SetBlockAppliedCallback registers callback to be called with the id of every block applied by the node,
+// it is only supported over websocket
+func (api *API) SetBlockAppliedCallback(callback func(blockID string)) error {
+	_, err := api.SubscribeBlockApplied(callback)
+	return err
+}
+
+// SubscribeBlockApplied is like SetBlockAppliedCallback, the returned unsubscribe function forgets the callback
+// so that it is not registered again when the transport reconnects
+func (api *API) SubscribeBlockApplied(callback func(blockID string)) (unsubscribe func(), err error) {
+	return api.setCallback("set_block_applied_callback", func(raw json.RawMessage) {
+		// the block id is sent as the single element of an array
+		var ids []string
+		if err := json.Unmarshal(raw, &ids); err == nil {
+			for _, id := range ids {
+				callback(id)
+			}
+			return
+		}
+		var id string
+		if err := json.Unmarshal(raw, &id); err == nil {
+			callback(id)
+		}
+	})
+}
+
 func (api *API) GetObjects(objectIds ...string) ([]json.RawMessage, error) {
 	var resp []json.RawMessage
 	err := api.call("get_objects", []interface{}{objectIds}, &resp)
@@ -150,18 +260,103 @@ func (api *API) GetAsset(symbol string) (*Asset, error) {
 	return resp[0], nil
 }
 
-//get_contract_account_by_name
+// GetAssetDetails gets the asset corresponding to the provided symbol or ID with its dynamic data
+func (api *API) GetAssetDetails(symbol string) (*Asset, error) {
+	asset, err := api.GetAsset(symbol)
+	if err != nil {
+		return nil, err
+	}
+	data, err := api.GetAssetDynamicData(asset.DynamicAssetDataID)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || data[0] == nil {
+		return nil, errors.Errorf("dynamic data %s of asset %s not exist", asset.DynamicAssetDataID, symbol)
+	}
+	asset.DynamicData = data[0]
+	return asset, nil
+}
+
+// GetAssetDynamicData gets the dynamic data objects 2.3.x, unknown ids are nil
+func (api *API) GetAssetDynamicData(ids ...string) ([]*AssetDynamicData, error) {
+	var resp []*AssetDynamicData
+	err := api.call("get_objects", []interface{}{ids}, &resp)
+	return resp, err
+}
+
+// GetContractAccountByName returns the contract account with its abi and code
+func (api *API) GetContractAccountByName(contract string) (*types.ContractAccountProperties, error) {
+	var resp *types.ContractAccountProperties
+	if err := api.call("get_contract_account_by_name", []interface{}{contract}, &resp); err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.Errorf("contract %s not exist", contract)
+	}
+	return resp, nil
+}
+
+// GetContractTables returns the tables declared in the abi of a contract
+func (api *API) GetContractTables(contract string) ([]types.Table, error) {
+	var resp []types.Table
+	err := api.call("get_contract_tables", []interface{}{contract}, &resp)
+	return resp, err
+}
+
+// GetTableRows returns a page of rows of a contract table, rows are decoded to JSON by the node using the contract abi
+func (api *API) GetTableRows(contract, table string, params TableRowsParams) (*TableRows, error) {
+	var resp TableRows
+	if err := api.call("get_table_rows_ex", []interface{}{contract, table, params}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+//get_witness_by_account
 func (api *API) GetWitnessByAccount(accountId string) (*Witness, error) {
 	var resp *Witness
 	if err := api.call("get_witness_by_account", []interface{}{accountId}, &resp); err != nil {
 		return nil, err
 	}
 	if resp == nil {
-		return nil, errors.Errorf("%s is not witness", accountId)
+		return nil, errors.Wrapf(ErrNotFound, "%s is not witness", accountId)
+	}
+	return resp, nil
+}
+
+// GetCommitteeMemberByAccount returns the committee member of the account, ErrNotFound when the account is none
+func (api *API) GetCommitteeMemberByAccount(accountId string) (*Committee, error) {
+	var resp *Committee
+	if err := api.call("get_committee_member_by_account", []interface{}{accountId}, &resp); err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.Wrapf(ErrNotFound, "%s is not committee member", accountId)
 	}
 	return resp, nil
 }
 
+// GetWitnesses returns the witnesses of the witness ids, nil for unknown ids
+func (api *API) GetWitnesses(ids ...string) ([]*Witness, error) {
+	var resp []*Witness
+	err := api.call("get_witnesses", []interface{}{ids}, &resp)
+	return resp, err
+}
+
+// GetCommitteeMembers returns the committee members of the committee member ids, nil for unknown ids
+func (api *API) GetCommitteeMembers(ids ...string) ([]*Committee, error) {
+	var resp []*Committee
+	err := api.call("get_committee_members", []interface{}{ids}, &resp)
+	return resp, err
+}
+
+// LookupVoteIDs returns the witnesses and committee members of the vote ids, nil for unknown ids
+func (api *API) LookupVoteIDs(voteIDs ...string) ([]*VoteObject, error) {
+	var resp []*VoteObject
+	err := api.call("lookup_vote_ids", []interface{}{voteIDs}, &resp)
+	return resp, err
+}
+
 // Semantically equivalent to get_account_balances, but takes a name instead of an ID.
 func (api *API) GetNamedAccountBalances(account string, assets ...string) ([]*types.AssetAmount, error) {
 	var resp []*types.AssetAmount
@@ -194,7 +389,61 @@ func (api *API) GetRequiredFee(ops []types.Operation, assetID string) ([]types.A
 
 		opsJSON = append(opsJSON, opArr)
 	}
-	if err := api.call("get_required_fees", []interface{}{opsJSON, assetID}, &resp); err != nil {
+	var raws []json.RawMessage
+	if err := api.call("get_required_fees", []interface{}{opsJSON, assetID}, &raws); err != nil {
+		return nil, err
+	}
+
+	for _, raw := range raws {
+		// the fee of a proposal comes as [fee, [fees of the proposed operations]]
+		var nested []json.RawMessage
+		if err := json.Unmarshal(raw, &nested); err == nil && len(nested) > 0 {
+			raw = nested[0]
+		}
+		var fee types.AssetAmount
+		if err := json.Unmarshal(raw, &fee); err != nil {
+			return nil, err
+		}
+		resp = append(resp, fee)
+	}
+	return resp, nil
+}
+
+// GetRequiredSignatures returns the keys of availableKeys needed to sign tx
+func (api *API) GetRequiredSignatures(tx *types.Transaction, availableKeys ...string) ([]string, error) {
+	var resp []string
+	if availableKeys == nil {
+		availableKeys = []string{}
+	}
+	if err := api.call("get_required_signatures", []interface{}{signedTransaction(tx), availableKeys}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPotentialSignatures returns all the keys that could sign tx
+func (api *API) GetPotentialSignatures(tx *types.Transaction) ([]string, error) {
+	var resp []string
+	if err := api.call("get_potential_signatures", []interface{}{signedTransaction(tx)}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// signedTransaction returns tx with an empty array of signatures when it has none, the node rejects null
+func signedTransaction(tx *types.Transaction) *types.Transaction {
+	if tx.Signatures != nil {
+		return tx
+	}
+	cp := *tx
+	cp.Signatures = []string{}
+	return &cp
+}
+
+// GetProposedTransactions returns the pending proposals that accountID is involved in
+func (api *API) GetProposedTransactions(accountID string) ([]*types.Proposal, error) {
+	var resp []*types.Proposal
+	if err := api.call("get_proposed_transactions", []interface{}{accountID}, &resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
@@ -6,11 +6,23 @@ import (
 )
 
 type Asset struct {
+	ID                 types.ObjectID     `json:"id"`
+	Symbol             string             `json:"symbol"`
+	Precision          uint8              `json:"precision"`
+	Issuer             string             `json:"issuer"`
+	Options            types.AssetOptions `json:"options"`
+	DynamicAssetDataID string             `json:"dynamic_asset_data_id"`
+	// DynamicData is only set by GetAssetDetails
+	DynamicData *AssetDynamicData `json:"dynamic_data,omitempty"`
+}
+
+// AssetDynamicData are the supplies of an asset, which change with every operation on it
+type AssetDynamicData struct {
 	ID                 types.ObjectID `json:"id"`
-	Symbol             string         `json:"symbol"`
-	Precision          uint8          `json:"precision"`
-	Issuer             string         `json:"issuer"`
-	DynamicAssetDataID string         `json:"dynamic_asset_data_id"`
+	CurrentSupply      types.Share    `json:"current_supply"`
+	ConfidentialSupply types.Share    `json:"confidential_supply"`
+	AccumulatedFees    types.Share    `json:"accumulated_fees"`
+	FeePool            types.Share    `json:"fee_pool"`
 }
 
 type BlockHeader struct {
@@ -126,6 +138,11 @@ func NewTableRowsParams() TableRowsParams {
 	return TableRowsParams{0, -1, 1, 10, false}
 }
 
+type TableRows struct {
+	Rows []json.RawMessage `json:"rows"`
+	More bool              `json:"more"`
+}
+
 type Witness struct {
 	Id                    string `json:"id"`
 	IsValid               bool   `json:"is_valid"`
@@ -149,6 +166,16 @@ type Committee struct {
 	IsValid    bool   `json:"is_valid"`
 }
 
+// VoteObject is a witness or a committee member found by its vote id, the account of the other kind is empty
+type VoteObject struct {
+	Id                     string `json:"id"`
+	VoteId                 string `json:"vote_id"`
+	WitnessAccount         string `json:"witness_account,omitempty"`
+	CommitteeMemberAccount string `json:"committee_member_account,omitempty"`
+	Url                    string `json:"url"`
+	IsValid                bool   `json:"is_valid"`
+}
+
 type GlobalProperties struct {
 	Properties string
 }
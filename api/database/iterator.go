@@ -0,0 +1,167 @@
+package database
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gxclient-go/types"
+	"math"
+	"strconv"
+)
+
+// TableRowsIterator walks all rows of a contract table between the bounds of its params,
+// fetching the next page with GetTableRows whenever the current one is consumed
+type TableRowsIterator struct {
+	api      *API
+	contract string
+	table    string
+	params   TableRowsParams
+	abi      types.Abi
+	keyName  string
+
+	rows    []json.RawMessage
+	pos     int
+	more    bool
+	fetched bool
+	current json.RawMessage
+	err     error
+}
+
+// NewTableRowsIterator creates an iterator over the rows of a contract table, the contract abi
+// is fetched to find the primary key used to compute the bound of the next page
+func (api *API) NewTableRowsIterator(contract, table string, params TableRowsParams) (*TableRowsIterator, error) {
+	account, err := api.GetContractAccountByName(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &TableRowsIterator{
+		api:      api,
+		contract: contract,
+		table:    table,
+		params:   params,
+		abi:      account.XAbi,
+	}
+
+	for _, t := range account.XAbi.Tables {
+		if t.Name != table {
+			continue
+		}
+		if len(t.KeyNames) > 0 {
+			it.keyName = t.KeyNames[0]
+		} else if s := account.XAbi.FindStruct(t.Type); s != nil && len(s.Fields) > 0 {
+			it.keyName = s.Fields[0].Name
+		}
+		return it, nil
+	}
+	return nil, errors.Errorf("table %s not found in contract %s", table, contract)
+}
+
+// Next advances to the next row, it returns false when all rows are read or an error occurred
+func (it *TableRowsIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.pos >= len(it.rows) {
+		if it.fetched && !it.more {
+			return false
+		}
+		if it.fetched && !it.advanceBound() {
+			return false
+		}
+
+		page, err := it.api.GetTableRows(it.contract, it.table, it.params)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.fetched = true
+		it.rows = page.Rows
+		it.more = page.More
+		it.pos = 0
+
+		if len(page.Rows) == 0 {
+			return false
+		}
+	}
+
+	it.current = it.rows[it.pos]
+	it.pos++
+	return true
+}
+
+// Row returns the JSON of the current row
+func (it *TableRowsIterator) Row() json.RawMessage {
+	return it.current
+}
+
+// Decode unmarshals the current row into v
+func (it *TableRowsIterator) Decode(v interface{}) error {
+	if it.current == nil {
+		return errors.New("no current row")
+	}
+	return json.Unmarshal(it.current, v)
+}
+
+// Abi returns the abi of the iterated contract
+func (it *TableRowsIterator) Abi() types.Abi {
+	return it.abi
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *TableRowsIterator) Err() error {
+	return it.err
+}
+
+// advanceBound moves the lower bound (or the upper bound when reversed) past the last row read
+func (it *TableRowsIterator) advanceBound() bool {
+	if len(it.rows) == 0 {
+		return false
+	}
+
+	key, err := it.rowKey(it.rows[len(it.rows)-1])
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if it.params.Reverse {
+		if key == 0 {
+			return false
+		}
+		it.params.UpperBound = int64(key - 1)
+		return true
+	}
+
+	if key >= math.MaxInt64 {
+		return false
+	}
+	it.params.LowerBound = int64(key + 1)
+	return true
+}
+
+func (it *TableRowsIterator) rowKey(row json.RawMessage) (uint64, error) {
+	if len(it.keyName) == 0 {
+		return 0, errors.Errorf("no primary key found for table %s", it.table)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(row, &fields); err != nil {
+		return 0, errors.Wrapf(err, "failed to unmarshal row: %s", string(row))
+	}
+	raw, ok := fields[it.keyName]
+	if !ok {
+		return 0, errors.Errorf("row has no primary key %s: %s", it.keyName, string(row))
+	}
+
+	// uint64 keys might be returned as strings
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return strconv.ParseUint(str, 10, 64)
+	}
+	var key uint64
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return 0, errors.Wrapf(err, "failed to parse primary key %s", string(raw))
+	}
+	return key, nil
+}
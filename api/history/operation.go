@@ -0,0 +1,69 @@
+package history
+
+import (
+	"encoding/json"
+	"gxclient-go/types"
+)
+
+// Operation decodes the operation of the history entry into its registered type,
+// unregistered types are returned as *types.UnknownOperation
+func (h *OperationHistory) Operation() (types.Operation, error) {
+	data, err := json.Marshal(h.Operations)
+	if err != nil {
+		return nil, err
+	}
+	return types.UnmarshalOperation(data)
+}
+
+// OperationResult decodes the result of the operation
+func (h *OperationHistory) OperationResult() (*types.OperationResult, error) {
+	data, err := json.Marshal(h.Result)
+	if err != nil {
+		return nil, err
+	}
+	var result types.OperationResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AsTransfer returns the operation if it is a transfer
+func (h *OperationHistory) AsTransfer() (*types.TransferOperation, bool) {
+	op, err := h.Operation()
+	if err != nil {
+		return nil, false
+	}
+	transfer, ok := op.(*types.TransferOperation)
+	return transfer, ok
+}
+
+// AsAccountCreate returns the operation if it is an account creation
+func (h *OperationHistory) AsAccountCreate() (*types.AccountCreateOperation, bool) {
+	op, err := h.Operation()
+	if err != nil {
+		return nil, false
+	}
+	create, ok := op.(*types.AccountCreateOperation)
+	return create, ok
+}
+
+// AsCallContract returns the operation if it is a contract call
+func (h *OperationHistory) AsCallContract() (*types.CallContractOperation, bool) {
+	op, err := h.Operation()
+	if err != nil {
+		return nil, false
+	}
+	call, ok := op.(*types.CallContractOperation)
+	return call, ok
+}
+
+// AsStakingCreate returns the operation if it is a staking creation
+func (h *OperationHistory) AsStakingCreate() (*types.StakingCreateOperation, bool) {
+	op, err := h.Operation()
+	if err != nil {
+		return nil, false
+	}
+	staking, ok := op.(*types.StakingCreateOperation)
+	return staking, ok
+}
@@ -1,6 +1,7 @@
 package login
 
 import (
+	"context"
 	"gxclient-go/rpc"
 	"strconv"
 )
@@ -9,10 +10,18 @@ const APIID = "1"
 
 type API struct {
 	caller rpc.Caller
+	ctx    context.Context
 }
 
 func NewAPI(caller rpc.Caller) *API {
-	return &API{caller}
+	return &API{caller: caller, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the API whose calls are bound to ctx
+func (api *API) WithContext(ctx context.Context) *API {
+	copied := *api
+	copied.ctx = ctx
+	return &copied
 }
 
 func (api *API) call(method string, args []interface{}, reply interface{}) error {
@@ -20,7 +29,7 @@ func (api *API) call(method string, args []interface{}, reply interface{}) error
 	if err != nil {
 		return err
 	}
-	return api.caller.Call(rpc.APIID(APIID), method, args, reply)
+	return rpc.CallContext(api.ctx, api.caller, rpc.APIID(APIID), method, args, reply)
 }
 
 func (api *API) GetApiByName(name string) (*uint8, error) {
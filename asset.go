@@ -0,0 +1,260 @@
+package gxclient_go
+
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gxclient-go/api/database"
+	"gxclient-go/types"
+	"strconv"
+	"strings"
+)
+
+// maxAssetPrecision is the largest number of decimals of an asset
+const maxAssetPrecision = 12
+
+// CreateAsset creates the asset symbol issued by the client account, with precision decimals and maxSupply
+// in whole units, e.g. "1000000". The max supply of options is replaced by maxSupply and an unset core
+// exchange rate defaults to 1 of the new asset, 1.3.0 standing for it, for 1 of the core asset.
+func (client *Client) CreateAsset(symbol string, precision uint8, maxSupply string, options types.AssetOptions, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	config, err := client.Database.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain config")
+	}
+	if err := validateAssetSymbol(symbol, config); err != nil {
+		return nil, err
+	}
+	if precision > maxAssetPrecision {
+		return nil, errors.Errorf("precision %d is larger than %d", precision, maxAssetPrecision)
+	}
+
+	supply, err := toShare(maxSupply, precision)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid max supply")
+	}
+	if config.GrapheneMaxShareSupply != "" {
+		max, err := strconv.ParseInt(config.GrapheneMaxShareSupply, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid max share supply of chain config")
+		}
+		if supply > max {
+			return nil, errors.Errorf("max supply %s is larger than %d in the smallest unit", maxSupply, max)
+		}
+	}
+
+	if _, err := client.Database.GetAsset(symbol); err == nil {
+		return nil, errors.Errorf("asset %s already exists", symbol)
+	}
+
+	options.MaxSupply = supply
+	if options.CoreExchangeRate == (types.Price{}) {
+		core, err := client.Database.GetAsset(config.GrapheneSymbol)
+		if err != nil {
+			return nil, err
+		}
+		options.CoreExchangeRate = types.Price{
+			Base:  types.AssetAmount{Amount: 1, AssetID: types.MustParseObjectID("1.3.0")},
+			Quote: types.AssetAmount{Amount: 1, AssetID: core.ID},
+		}
+	}
+
+	issuer := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewAssetCreateOperation(issuer, symbol, precision, options, types.AssetAmount{})
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// IssueAsset issues amountAsset, e.g. "100 TOKEN", of an asset issued by the client account to the account to,
+// the memo is encrypted for to
+func (client *Client) IssueAsset(to, amountAsset, memo, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	amount, asset, err := client.parseAssetAmount(amountAsset, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.checkIssuer(asset); err != nil {
+		return nil, err
+	}
+	if supply := int64(asset.DynamicData.CurrentSupply); int64(amount.Amount) > asset.Options.MaxSupply-supply {
+		return nil, errors.Errorf("issuing %s exceeds the max supply of %s", amountAsset, asset.Symbol)
+	}
+
+	toAccount, err := client.Database.GetAccount(to)
+	if err != nil {
+		return nil, err
+	}
+	memoOb, err := client.newMemo(toAccount, memo)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewAssetIssueOperation(issuer, types.MustParseObjectID(toAccount.ID.String()), amount, types.AssetAmount{}, memoOb)
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// ReserveAsset takes amountAsset, e.g. "100 TOKEN", of the balance of the client account out of the supply
+func (client *Client) ReserveAsset(amountAsset, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	amount, _, err := client.parseAssetAmount(amountAsset, false)
+	if err != nil {
+		return nil, err
+	}
+
+	payer := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewAssetReserveOperation(payer, amount, types.AssetAmount{})
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// UpdateAsset replaces the options of the asset symbol issued by the client account, options are usually
+// the Options of the asset with some changes. A non empty newIssuer transfers the asset to that account.
+func (client *Client) UpdateAsset(symbol, newIssuer string, options types.AssetOptions, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	asset, err := client.Database.GetAssetDetails(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.checkIssuer(asset); err != nil {
+		return nil, err
+	}
+	if options.MaxSupply < int64(asset.DynamicData.CurrentSupply) {
+		return nil, errors.Errorf("max supply %d is lower than the current supply %d of %s",
+			options.MaxSupply, asset.DynamicData.CurrentSupply, symbol)
+	}
+
+	var newIssuerID *types.ObjectID
+	if newIssuer != "" {
+		account, err := client.Database.GetAccount(newIssuer)
+		if err != nil {
+			return nil, err
+		}
+		if account.ID.String() == asset.Issuer {
+			return nil, errors.Errorf("%s is already the issuer of %s", newIssuer, symbol)
+		}
+		id := types.MustParseObjectID(account.ID.String())
+		newIssuerID = &id
+	}
+
+	issuer := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewAssetUpdateOperation(issuer, asset.ID, newIssuerID, options, types.AssetAmount{})
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// FundFeePool adds amountAsset of the core asset, e.g. "100 GXC", to the fee pool of the asset symbol,
+// which pays the fees paid in symbol
+func (client *Client) FundFeePool(symbol, amountAsset, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	asset, err := client.Database.GetAsset(symbol)
+	if err != nil {
+		return nil, err
+	}
+	amount, core, err := client.parseAssetAmount(amountAsset, false)
+	if err != nil {
+		return nil, err
+	}
+	config, err := client.Database.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain config")
+	}
+	if core.Symbol != config.GrapheneSymbol {
+		return nil, errors.Errorf("fee pool is funded in %s, not in %s", config.GrapheneSymbol, core.Symbol)
+	}
+
+	from := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewAssetFundFeePoolOperation(from, asset.ID, int64(amount.Amount), types.AssetAmount{})
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// parseAssetAmount reads an amount and a symbol separated by a space, details gets the dynamic data of the asset
+func (client *Client) parseAssetAmount(amountAsset string, details bool) (types.AssetAmount, *database.Asset, error) {
+	amountAndSymbol := strings.Split(amountAsset, " ")
+	if len(amountAndSymbol) != 2 {
+		return types.AssetAmount{}, nil, errors.Errorf("amount %s is not an amount and a symbol", amountAsset)
+	}
+
+	var asset *database.Asset
+	var err error
+	if details {
+		asset, err = client.Database.GetAssetDetails(amountAndSymbol[1])
+	} else {
+		asset, err = client.Database.GetAsset(amountAndSymbol[1])
+	}
+	if err != nil {
+		return types.AssetAmount{}, nil, err
+	}
+
+	amount, err := toShare(amountAndSymbol[0], asset.Precision)
+	if err != nil {
+		return types.AssetAmount{}, nil, errors.Wrapf(err, "invalid amount %s", amountAsset)
+	}
+	return types.AssetAmount{Amount: uint64(amount), AssetID: asset.ID}, asset, nil
+}
+
+func (client *Client) checkIssuer(asset *database.Asset) error {
+	if asset.Issuer != client.account.ID.String() {
+		return errors.Errorf("asset %s is issued by %s, not by %s", asset.Symbol, asset.Issuer, client.account.ID.String())
+	}
+	return nil
+}
+
+// newMemo encrypts memo for the account to, an empty memo gives no memo
+func (client *Client) newMemo(to *types.Account, memo string) (*types.Memo, error) {
+	if memo == "" {
+		return nil, nil
+	}
+	if client.memoPriKey == nil {
+		return nil, errors.New("no memo key to encrypt the memo")
+	}
+	if to.Options.MemoKey.IsNul() {
+		return nil, errors.Errorf("account %s has no memo key", to.Name)
+	}
+
+	memoOb := &types.Memo{
+		From:  client.account.Options.MemoKey,
+		To:    to.Options.MemoKey,
+		Nonce: types.GetNonce(),
+	}
+	if err := memoOb.Encrypt(client.memoPriKey, memo); err != nil {
+		return nil, err
+	}
+	return memoOb, nil
+}
+
+// toShare converts a positive amount in whole units into the smallest unit of an asset with precision decimals
+func toShare(amount string, precision uint8) (int64, error) {
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return 0, err
+	}
+	if d.Sign() <= 0 {
+		return 0, errors.Errorf("%s is not positive", amount)
+	}
+	shifted := d.Shift(int32(precision))
+	if !shifted.Equal(shifted.Truncate(0)) {
+		return 0, errors.Errorf("%s has more than %d decimals", amount, precision)
+	}
+	if shifted.Cmp(decimal.New(1, 18)) >= 0 {
+		return 0, errors.Errorf("%s is too large", amount)
+	}
+	return shifted.IntPart(), nil
+}
+
+// validateAssetSymbol checks symbol against the rules of the chain: its length is within the bounds of config,
+// it starts with an upper case letter, ends with an upper case letter or a digit and has only upper case
+// letters, digits and at most one dot
+func validateAssetSymbol(symbol string, config *database.Config) error {
+	if len(symbol) < int(config.GrapheneMinAssetSymbolLength) || len(symbol) > int(config.GrapheneMaxAssetSymbolLength) {
+		return errors.Errorf("asset symbol %s must have %d to %d characters", symbol,
+			config.GrapheneMinAssetSymbolLength, config.GrapheneMaxAssetSymbolLength)
+	}
+	if symbol[0] < 'A' || symbol[0] > 'Z' {
+		return errors.Errorf("asset symbol %s must start with an upper case letter", symbol)
+	}
+	last := symbol[len(symbol)-1]
+	if !(last >= 'A' && last <= 'Z' || last >= '0' && last <= '9') {
+		return errors.Errorf("asset symbol %s must end with an upper case letter or a digit", symbol)
+	}
+	for _, c := range symbol {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.') {
+			return errors.Errorf("asset symbol %s has invalid character %q", symbol, c)
+		}
+	}
+	if strings.Count(symbol, ".") > 1 {
+		return errors.Errorf("asset symbol %s has more than one dot", symbol)
+	}
+	return nil
+}
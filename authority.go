@@ -0,0 +1,127 @@
+package gxclient_go
+
+import (
+	"github.com/pkg/errors"
+	"gxclient-go/types"
+)
+
+// maxSigCheckDepth is the depth of nested account authorities checked by the chain
+const maxSigCheckDepth = 2
+
+// ErrAuthorityNotSatisfied is returned by VerifyAuthority when the signatures do not reach the weight threshold
+var ErrAuthorityNotSatisfied = errors.New("signatures do not satisfy the authority")
+
+// VerifyAuthority checks that the keys recovered from the signatures of stx reach the weight threshold of
+// authority, through its key, address and account auths. The active authorities of the accounts in
+// account auths are fetched from the node and checked the same way, nested up to the depth checked by the chain.
+// It returns ErrAuthorityNotSatisfied when the threshold is not reached.
+func (client *Client) VerifyAuthority(stx *types.SignedTransaction, authority *types.Authority) error {
+	keys, err := stx.SignerKeys(client.chainID)
+	if err != nil {
+		return err
+	}
+
+	checker := &authorityChecker{
+		client:   client,
+		signers:  map[string]bool{},
+		accounts: map[string]*types.Account{},
+	}
+	for _, key := range keys {
+		checker.signers[key.String()] = true
+		address, err := key.ToAddress()
+		if err != nil {
+			return err
+		}
+		checker.signers[address.String()] = true
+	}
+
+	ok, err := checker.check(authority, 0)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAuthorityNotSatisfied
+	}
+	return nil
+}
+
+type authorityChecker struct {
+	client *Client
+	// signers holds the public keys and the addresses of the signatures
+	signers  map[string]bool
+	accounts map[string]*types.Account
+}
+
+func (c *authorityChecker) check(authority *types.Authority, depth int) (bool, error) {
+	threshold := uint64(authority.WeightThreshold)
+	var total uint64
+
+	for key, weight := range authority.KeyAuths {
+		if key != nil && !key.IsNul() && c.signers[key.String()] {
+			total += uint64(weight)
+			if total >= threshold {
+				return true, nil
+			}
+		}
+	}
+
+	for address, weight := range authority.AddressAuths {
+		if address != nil && c.signers[address.String()] {
+			total += uint64(weight)
+			if total >= threshold {
+				return true, nil
+			}
+		}
+	}
+
+	if depth >= maxSigCheckDepth || len(authority.AccountAuths) == 0 {
+		return total >= threshold, nil
+	}
+
+	if err := c.fetchAccounts(authority.AccountAuths); err != nil {
+		return false, err
+	}
+	for id, weight := range authority.AccountAuths {
+		account := c.accounts[id.String()]
+		if account == nil {
+			continue
+		}
+		ok, err := c.check(&account.Active, depth+1)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			total += uint64(weight)
+			if total >= threshold {
+				return true, nil
+			}
+		}
+	}
+	return total >= threshold, nil
+}
+
+// fetchAccounts gets the accounts of auths not fetched yet in one call
+func (c *authorityChecker) fetchAccounts(auths types.AccountAuthsMap) error {
+	var ids []string
+	for id := range auths {
+		if _, ok := c.accounts[id.String()]; !ok {
+			ids = append(ids, id.String())
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	accounts, err := c.client.Database.GetAccountsByIds(ids...)
+	if err != nil {
+		return errors.Wrap(err, "failed to get accounts of account auths")
+	}
+	for i, id := range ids {
+		// unknown accounts are kept as nil so that they are not fetched again
+		c.accounts[id] = nil
+		if i < len(accounts) {
+			c.accounts[id] = accounts[i]
+		}
+	}
+	return nil
+}
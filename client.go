@@ -2,6 +2,7 @@ package gxclient_go
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"github.com/pkg/errors"
@@ -11,6 +12,7 @@ import (
 	"gxclient-go/api/history"
 	"gxclient-go/api/login"
 	"gxclient-go/rpc"
+	"gxclient-go/rpc/failover"
 	"gxclient-go/rpc/http"
 	"gxclient-go/rpc/websocket"
 	"gxclient-go/sign"
@@ -40,8 +42,10 @@ type Client struct {
 
 	chainID string
 
-	activePriKey *types.PrivateKey
+	// signer signs transactions with the active key
+	signer types.Signer
 
+	// memoPriKey is nil when the client has no memo key
 	memoPriKey *types.PrivateKey
 
 	account *types.Account
@@ -49,41 +53,105 @@ type Client struct {
 
 // NewClient creates a new RPC client
 func NewClient(actPriKeyWif, memoPriKeyWif, accountName, url string) (*Client, error) {
-	// transport
-	var cc rpc.CallCloser
-	var err error
+	return NewClientContext(context.Background(), actPriKeyWif, memoPriKeyWif, accountName, url)
+}
+
+// NewClientContext creates a new RPC client, ctx bounds the handshake with the node
+func NewClientContext(ctx context.Context, actPriKeyWif, memoPriKeyWif, accountName, url string) (*Client, error) {
+	signer, memoKey, err := loadKeys(actPriKeyWif, memoPriKeyWif)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(ctx, cc, signer, memoKey, accountName, strings.HasPrefix(url, "http"))
+}
+
+// NewClientWithSigner creates a new RPC client signing transactions with signer, the active key of the account
+// does not have to live in the process. memoPriKeyWif may be empty, the client then neither encrypts memos
+// nor signs messages.
+func NewClientWithSigner(signer types.Signer, memoPriKeyWif, accountName, url string) (*Client, error) {
+	return NewClientWithSignerContext(context.Background(), signer, memoPriKeyWif, accountName, url)
+}
+
+// NewClientWithSignerContext creates a new RPC client signing transactions with signer,
+// ctx bounds the handshake with the node
+func NewClientWithSignerContext(ctx context.Context, signer types.Signer, memoPriKeyWif, accountName, url string) (*Client, error) {
+	var memoKey *types.PrivateKey
+	if memoPriKeyWif != "" {
+		var err error
+		memoKey, err = types.NewPrivateKeyFromWif(memoPriKeyWif)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to init memo private key")
+		}
+	}
+
+	cc, err := dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(ctx, cc, signer, memoKey, accountName, strings.HasPrefix(url, "http"))
+}
+
+// dial returns the transport of url, http(s) or websocket
+func dial(url string) (rpc.CallCloser, error) {
 	if strings.HasPrefix(url, "http") || strings.HasPrefix(url, "https") {
-		cc = http.NewTransport(url)
-	} else {
-		cc, err = websocket.NewTransport(url)
+		return http.NewTransport(url), nil
+	}
+	return websocket.NewTransport(url)
+}
+
+// NewClientWithNodes creates a new RPC client failing over between several nodes,
+// all nodes must report the same chain id
+func NewClientWithNodes(actPriKeyWif, memoPriKeyWif, accountName string, urls []string) (*Client, error) {
+	return NewClientWithNodesContext(context.Background(), actPriKeyWif, memoPriKeyWif, accountName, urls)
+}
+
+// NewClientWithNodesContext creates a new RPC client failing over between several nodes,
+// ctx bounds the handshake with the nodes
+func NewClientWithNodesContext(ctx context.Context, actPriKeyWif, memoPriKeyWif, accountName string, urls []string) (*Client, error) {
+	signer, memoKey, err := loadKeys(actPriKeyWif, memoPriKeyWif)
+	if err != nil {
+		return nil, err
 	}
+	cc, err := failover.NewTransport(urls)
 	if err != nil {
 		return nil, err
 	}
+	// the failover transport resolves api names on every node itself
+	return newClient(ctx, cc, signer, memoKey, accountName, true)
+}
 
-	client := &Client{cc: cc}
+// loadKeys returns the signer of the active key and the memo key
+func loadKeys(actPriKeyWif, memoPriKeyWif string) (types.Signer, *types.PrivateKey, error) {
 	activeKey, err := types.NewPrivateKeyFromWif(actPriKeyWif)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to init active private key")
+		return nil, nil, errors.Wrap(err, "failed to init active private key")
 	}
-	client.activePriKey = activeKey
 
 	memoKey, err := types.NewPrivateKeyFromWif(memoPriKeyWif)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to init memo private key")
+		return nil, nil, errors.Wrap(err, "failed to init memo private key")
 	}
-	client.memoPriKey = memoKey
+	return types.NewKeySigner(activeKey), memoKey, nil
+}
 
-	if strings.HasPrefix(url, "http") || strings.HasPrefix(url, "https") {
+// newClient binds the APIs, named APIs are addressed by name instead of through login_api
+func newClient(ctx context.Context, cc rpc.CallCloser, signer types.Signer, memoKey *types.PrivateKey, accountName string, named bool) (*Client, error) {
+	client := &Client{cc: cc, signer: signer, memoPriKey: memoKey}
+
+	if named {
 		client.Database = database.NewAPI("database", cc)
-		chainID, err := client.Database.GetChainId()
+		chainID, err := client.Database.WithContext(ctx).GetChainId()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get database ID")
 		}
 		client.chainID = chainID
 		client.History = history.NewAPI("history", cc)
 		client.Broadcast = broadcast.NewAPI("network_broadcast", cc)
-		account, err := client.Database.GetAccount(accountName)
+		account, err := client.Database.WithContext(ctx).GetAccount(accountName)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to init account")
 		}
@@ -96,34 +164,34 @@ func NewClient(actPriKeyWif, memoPriKeyWif, accountName, url string) (*Client, e
 	client.Login = loginAPI
 
 	// database
-	databaseAPIID, err := loginAPI.Database()
+	databaseAPIID, err := loginAPI.WithContext(ctx).Database()
 	if err != nil {
 		return nil, err
 	}
 	client.Database = database.NewAPI(databaseAPIID, client.cc)
 
 	// database ID
-	chainID, err := client.Database.GetChainId()
+	chainID, err := client.Database.WithContext(ctx).GetChainId()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get database ID")
 	}
 	client.chainID = chainID
 
 	// history
-	historyAPIID, err := loginAPI.History()
+	historyAPIID, err := loginAPI.WithContext(ctx).History()
 	if err != nil {
 		return nil, err
 	}
 	client.History = history.NewAPI(historyAPIID, client.cc)
 
 	// network broadcast
-	networkBroadcastAPIID, err := loginAPI.NetworkBroadcast()
+	networkBroadcastAPIID, err := loginAPI.WithContext(ctx).NetworkBroadcast()
 	if err != nil {
 		return nil, err
 	}
 	client.Broadcast = broadcast.NewAPI(networkBroadcastAPIID, client.cc)
 
-	account, err := client.Database.GetAccount(accountName)
+	account, err := client.Database.WithContext(ctx).GetAccount(accountName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init account")
 	}
@@ -132,12 +200,123 @@ func NewClient(actPriKeyWif, memoPriKeyWif, accountName, url string) (*Client, e
 	return client, nil
 }
 
+// WithContext returns a copy of the client whose calls to the node are bound to ctx,
+// the copy shares the connection of the client
+func (client *Client) WithContext(ctx context.Context) *Client {
+	copied := *client
+	copied.Database = client.Database.WithContext(ctx)
+	copied.History = client.History.WithContext(ctx)
+	copied.Broadcast = client.Broadcast.WithContext(ctx)
+	if client.Login != nil {
+		copied.Login = client.Login.WithContext(ctx)
+	}
+	return &copied
+}
+
 // Close should be used to close the client when no longer needed.
 // It simply calls Close() on the underlying CallCloser.
 func (client *Client) Close() error {
 	return client.cc.Close()
 }
 
+// blockPollInterval is how often the head block is checked when no block notice arrives,
+// GXChain produces a block every 3 seconds
+const blockPollInterval = 3 * time.Second
+
+// SubscribeBlocks streams blocks in order starting at fromBlock, 0 starts after the current head block.
+// Blocks up to the head block are sent first, then new blocks as the node applies them,
+// woken by set_block_applied_callback over websocket and by polling over http.
+// With irreversibleOnly only blocks up to the last irreversible block are sent, otherwise
+// a block sent might be dropped by a fork later. Blocks missed while the connection was lost
+// are fetched on the next wake up, so none is skipped or sent twice.
+// The channel is closed and the block applied callback unset once ctx is done.
+func (client *Client) SubscribeBlocks(ctx context.Context, fromBlock uint32, irreversibleOnly bool) (<-chan *database.Block, error) {
+	db := client.Database.WithContext(ctx)
+	if fromBlock == 0 {
+		props, err := db.GetDynamicGlobalProperties()
+		if err != nil {
+			return nil, err
+		}
+		fromBlock = props.HeadBlockNumber + 1
+		if irreversibleOnly {
+			fromBlock = props.LastIrreversibleBlockNum + 1
+		}
+	}
+
+	wake := make(chan struct{}, 1)
+	unsubscribe, err := client.Database.SubscribeBlockApplied(func(blockID string) {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set block applied callback")
+	}
+
+	blocks := make(chan *database.Block)
+	go func() {
+		defer close(blocks)
+		defer unsubscribe()
+
+		ticker := time.NewTicker(blockPollInterval)
+		defer ticker.Stop()
+
+		next := fromBlock
+		for {
+			var err error
+			next, err = sendBlocks(ctx, db, next, irreversibleOnly, blocks)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("failed to get blocks from %d: %v", next, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-wake:
+			case <-ticker.C:
+			}
+		}
+	}()
+	return blocks, nil
+}
+
+// sendBlocks sends the blocks from next up to the head or last irreversible block,
+// it returns the number of the next block to send
+func sendBlocks(ctx context.Context, db *database.API, next uint32, irreversibleOnly bool, blocks chan<- *database.Block) (uint32, error) {
+	props, err := db.GetDynamicGlobalProperties()
+	if err != nil {
+		return next, err
+	}
+	last := props.HeadBlockNumber
+	if irreversibleOnly {
+		last = props.LastIrreversibleBlockNum
+	}
+
+	for next <= last {
+		to := last
+		if to-next >= 100 {
+			to = next + 99
+		}
+		page, err := db.GetBlocks(next, to)
+		if err != nil {
+			return next, err
+		}
+		for _, block := range page {
+			if block == nil || block.BlockId == "" {
+				return next, errors.Errorf("block %d not found", next)
+			}
+			select {
+			case blocks <- block:
+			case <-ctx.Done():
+				return next, ctx.Err()
+			}
+			next++
+		}
+	}
+	return next, nil
+}
+
 // Transfer a certain amount of the given asset
 func (client *Client) Transfer(to, memo, amountAsset, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
 	toAccount, err := client.Database.GetAccount(to)
@@ -178,6 +357,11 @@ func (client *Client) Transfer(to, memo, amountAsset, feeSymbol string, broadcas
 	//memoKey为空时不生成memo
 	if len(memo) < 0 || toAccount.Options.MemoKey.IsNul() || client.account.Options.MemoKey.IsNul() {
 		memoOb = nil
+	} else if client.memoPriKey == nil {
+		if memo != "" {
+			return nil, errors.New("no memo key to encrypt the memo")
+		}
+		memoOb = nil
 	} else {
 		memoOb.From = client.account.Options.MemoKey
 		memoOb.To = toAccount.Options.MemoKey
@@ -196,7 +380,7 @@ func (client *Client) Transfer(to, memo, amountAsset, feeSymbol string, broadcas
 	}
 	op.Fee.Amount = fees[0].Amount
 
-	stx, err := client.sign([]string{client.activePriKey.ToWIF()}, op)
+	stx, err := client.sign(op)
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +452,7 @@ func (client *Client) CreateStaking(to string, amount float64, programId, feeSym
 	}
 	op.Fee.Amount = fees[0].Amount
 
-	stx, err := client.sign([]string{client.activePriKey.ToWIF()}, op)
+	stx, err := client.sign(op)
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +499,7 @@ func (client *Client) UpdateStaking(to, stakingId, feeSymbol string, broadcast b
 	}
 	op.Fee.Amount = fees[0].Amount
 
-	stx, err := client.sign([]string{client.activePriKey.ToWIF()}, op)
+	stx, err := client.sign(op)
 	if err != nil {
 		return nil, err
 	}
@@ -351,7 +535,44 @@ func (client *Client) ClaimStaking(stakingId, feeSymbol string, broadcast bool)
 	}
 	op.Fee.Amount = fees[0].Amount
 
-	stx, err := client.sign([]string{client.activePriKey.ToWIF()}, op)
+	stx, err := client.sign(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(types.TransactionResult)
+	result.SignedTransaction = stx
+
+	if broadcast {
+		resp, err := client.broadcastSync(stx)
+		if err != nil {
+			return result, err
+		}
+		result.BroadcastResponse = resp
+	}
+	return result, err
+}
+
+// Deploy a contract
+func (client *Client) CreateContract(name string, code []byte, abi types.Abi, vmType, vmVersion, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	fee, err := client.Database.GetAsset(feeSymbol)
+	if err != nil {
+		return nil, err
+	}
+	feeAssets := types.AssetAmount{
+		AssetID: fee.ID,
+		Amount:  0,
+	}
+
+	op := types.NewCreateContractOperation(types.MustParseObjectID(client.account.ID.String()), name, vmType, vmVersion, code, abi, feeAssets)
+
+	fees, err := client.Database.GetRequiredFee([]types.Operation{op}, feeAssets.AssetID.String())
+	if err != nil {
+		return nil, err
+	}
+	op.Fee.Amount = fees[0].Amount
+
+	stx, err := client.sign(op)
 	if err != nil {
 		return nil, err
 	}
@@ -369,7 +590,133 @@ func (client *Client) ClaimStaking(stakingId, feeSymbol string, broadcast bool)
 	return result, err
 }
 
-func (client *Client) sign(wifs []string, operations ...types.Operation) (*types.SignedTransaction, error) {
+// Call a contract method, amountAsset like "1.5 GXC" is optional.
+// params are packed with the contract abi, pass []byte to send already packed action data
+func (client *Client) CallContract(contract, method string, params interface{}, amountAsset, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	contractAccount, err := client.Database.GetContractAccountByName(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := params.([]byte)
+	if !ok {
+		data, err = contractAccount.XAbi.SerializeActionData(method, params)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize params of %s", method)
+		}
+	}
+
+	var amountAssets *types.AssetAmount
+	if len(amountAsset) > 0 {
+		amountAndSymbol := strings.Split(amountAsset, " ")
+		if len(amountAndSymbol) != 2 {
+			return nil, errors.New("amountAsset incorrect format!")
+		}
+
+		amountSymbol, err := client.Database.GetAsset(amountAndSymbol[1])
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := strconv.ParseFloat(amountAndSymbol[0], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		amountAssets = &types.AssetAmount{
+			AssetID: amountSymbol.ID,
+			Amount:  uint64(decimal.NewFromFloat(amount).Mul(decimal.NewFromFloat(math.Pow10(int(amountSymbol.Precision)))).IntPart()),
+		}
+	}
+
+	fee, err := client.Database.GetAsset(feeSymbol)
+	if err != nil {
+		return nil, err
+	}
+	feeAssets := types.AssetAmount{
+		AssetID: fee.ID,
+		Amount:  0,
+	}
+
+	op := types.NewCallContractOperation(types.MustParseObjectID(client.account.ID.String()), contractAccount.ID, method, data, amountAssets, feeAssets)
+
+	fees, err := client.Database.GetRequiredFee([]types.Operation{op}, feeAssets.AssetID.String())
+	if err != nil {
+		return nil, err
+	}
+	op.Fee.Amount = fees[0].Amount
+
+	stx, err := client.sign(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(types.TransactionResult)
+	result.SignedTransaction = stx
+
+	if broadcast {
+		resp, err := client.broadcastSync(stx)
+		if err != nil {
+			return result, err
+		}
+		result.BroadcastResponse = resp
+	}
+	return result, err
+}
+
+// Update the code and abi of a contract, newOwner is optional
+func (client *Client) UpdateContract(contract, newOwner string, code []byte, abi types.Abi, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	contractAccount, err := client.Database.GetAccount(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	var newOwnerId *types.ObjectID
+	if len(newOwner) > 0 {
+		newOwnerAccount, err := client.Database.GetAccount(newOwner)
+		if err != nil {
+			return nil, err
+		}
+		id := types.MustParseObjectID(newOwnerAccount.ID.String())
+		newOwnerId = &id
+	}
+
+	fee, err := client.Database.GetAsset(feeSymbol)
+	if err != nil {
+		return nil, err
+	}
+	feeAssets := types.AssetAmount{
+		AssetID: fee.ID,
+		Amount:  0,
+	}
+
+	op := types.NewUpdateContractOperation(types.MustParseObjectID(client.account.ID.String()), types.MustParseObjectID(contractAccount.ID.String()), newOwnerId, code, abi, feeAssets)
+
+	fees, err := client.Database.GetRequiredFee([]types.Operation{op}, feeAssets.AssetID.String())
+	if err != nil {
+		return nil, err
+	}
+	op.Fee.Amount = fees[0].Amount
+
+	stx, err := client.sign(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(types.TransactionResult)
+	result.SignedTransaction = stx
+
+	if broadcast {
+		resp, err := client.broadcastSync(stx)
+		if err != nil {
+			return result, err
+		}
+		result.BroadcastResponse = resp
+	}
+	return result, err
+}
+
+func (client *Client) sign(operations ...types.Operation) (*types.SignedTransaction, error) {
 	props, err := client.Database.GetDynamicGlobalProperties()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get dynamic global properties")
@@ -405,13 +752,52 @@ func (client *Client) sign(wifs []string, operations ...types.Operation) (*types
 	s := hex.EncodeToString(b.Bytes())
 	fmt.Println(s)
 
-	if err = stx.Sign(wifs, client.chainID); err != nil {
+	if err = stx.SignWith(client.chainID, client.signer); err != nil {
 		return nil, errors.Wrap(err, "failed to sign the transaction")
 	}
 
 	return stx, nil
 }
 
+// signAndBroadcast sets the required fee in feeSymbol on op, signs it with the signer and broadcasts it
+func (client *Client) signAndBroadcast(op types.Operation, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	fee, err := client.Database.GetAsset(feeSymbol)
+	if err != nil {
+		return nil, err
+	}
+	if err := types.SetOperationFee(op, types.AssetAmount{AssetID: fee.ID}); err != nil {
+		return nil, err
+	}
+
+	fees, err := client.Database.GetRequiredFee([]types.Operation{op}, fee.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(fees) != 1 {
+		return nil, errors.Errorf("got %d fees for 1 operation", len(fees))
+	}
+	if err := types.SetOperationFee(op, fees[0]); err != nil {
+		return nil, err
+	}
+
+	stx, err := client.sign(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(types.TransactionResult)
+	result.SignedTransaction = stx
+
+	if broadcast {
+		resp, err := client.broadcastSync(stx)
+		if err != nil {
+			return result, err
+		}
+		result.BroadcastResponse = resp
+	}
+	return result, err
+}
+
 func (client *Client) broadcast(stx *types.SignedTransaction) error {
 	return client.Broadcast.BroadcastTransaction(stx.Transaction)
 }
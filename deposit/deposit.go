@@ -0,0 +1,155 @@
+package deposit
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	gxc "gxclient-go"
+	"gxclient-go/api/database"
+	"gxclient-go/types"
+	"strconv"
+)
+
+// Deposit is an irreversible transfer to the watched account
+type Deposit struct {
+	// ID is unique per operation, <block num>.<trx in block>.<op in trx>
+	ID         string
+	TxID       string
+	BlockNum   uint32
+	TrxInBlock uint32
+	OpInTrx    uint32
+	Timestamp  types.Time
+
+	From   types.ObjectID
+	Amount types.AssetAmount
+
+	// Memo is the decrypted memo, MemoErr is set when the transfer has a memo that cannot be decrypted
+	Memo    string
+	MemoErr error
+}
+
+// Monitor watches an account for incoming transfers
+type Monitor struct {
+	client  *gxc.Client
+	account types.ObjectID
+	memoKey *types.PrivateKey
+	store   CheckpointStore
+}
+
+// NewMonitor creates a monitor of the transfers to account, memos are decrypted with memoPriKeyWif.
+// The store keeps the last block whose deposits were handled.
+func NewMonitor(client *gxc.Client, account, memoPriKeyWif string, store CheckpointStore) (*Monitor, error) {
+	acc, err := client.Database.GetAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, err := types.ParseObjectID(acc.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	memoKey, err := types.NewPrivateKeyFromWif(memoPriKeyWif)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init memo private key")
+	}
+
+	return &Monitor{
+		client:  client,
+		account: accountID,
+		memoKey: memoKey,
+		store:   store,
+	}, nil
+}
+
+// Run calls handler for every deposit of every irreversible block, starting after the checkpoint
+// or after the current last irreversible block when there is none. The checkpoint is saved once all
+// deposits of a block are handled, so after a restart a block is handled again only if its
+// handling failed, deposits should be deduplicated by ID.
+// Run returns the error of the handler or of the store, or ctx.Err() once ctx is done.
+func (m *Monitor) Run(ctx context.Context, handler func(deposit *Deposit) error) error {
+	checkpoint, ok, err := m.store.Load()
+	if err != nil {
+		return errors.Wrap(err, "failed to load checkpoint")
+	}
+	if !ok {
+		props, err := m.client.Database.WithContext(ctx).GetDynamicGlobalProperties()
+		if err != nil {
+			return err
+		}
+		checkpoint = props.LastIrreversibleBlockNum
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blocks, err := m.client.SubscribeBlocks(ctx, checkpoint+1, true)
+	if err != nil {
+		return err
+	}
+
+	for block := range blocks {
+		blockNum, err := blockNumber(block)
+		if err != nil {
+			return err
+		}
+		if blockNum != checkpoint+1 {
+			return errors.Errorf("expected block %d, got %d", checkpoint+1, blockNum)
+		}
+
+		for _, deposit := range m.deposits(block, blockNum) {
+			if err := handler(deposit); err != nil {
+				return err
+			}
+		}
+
+		if err := m.store.Save(blockNum); err != nil {
+			return errors.Wrapf(err, "failed to save checkpoint %d", blockNum)
+		}
+		checkpoint = blockNum
+	}
+	return ctx.Err()
+}
+
+// deposits returns the transfers to the watched account in block
+func (m *Monitor) deposits(block *database.Block, blockNum uint32) []*Deposit {
+	var deposits []*Deposit
+	for trx, tx := range block.Transactions {
+		for op, operation := range tx.Operations {
+			transfer, ok := operation.(*types.TransferOperation)
+			if !ok || transfer.To != m.account {
+				continue
+			}
+
+			deposit := &Deposit{
+				ID:         fmt.Sprintf("%d.%d.%d", blockNum, trx, op),
+				BlockNum:   blockNum,
+				TrxInBlock: uint32(trx),
+				OpInTrx:    uint32(op),
+				Timestamp:  block.Timestamp,
+				From:       transfer.From,
+				Amount:     transfer.Amount,
+			}
+			if trx < len(block.TransactionIds) {
+				deposit.TxID = block.TransactionIds[trx]
+			}
+			if transfer.Memo != nil && transfer.Memo.Message.Length() > 0 {
+				deposit.Memo, deposit.MemoErr = transfer.Memo.Decrypt(m.memoKey)
+			}
+			deposits = append(deposits, deposit)
+		}
+	}
+	return deposits
+}
+
+// blockNumber reads the block number from the first 4 bytes of the block id
+func blockNumber(block *database.Block) (uint32, error) {
+	if len(block.BlockId) < 8 {
+		return 0, errors.Errorf("invalid block id %q", block.BlockId)
+	}
+	num, err := strconv.ParseUint(block.BlockId[:8], 16, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid block id %q", block.BlockId)
+	}
+	return uint32(num), nil
+}
@@ -0,0 +1,84 @@
+package deposit
+
+import (
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CheckpointStore keeps the number of the last block whose deposits were handled
+type CheckpointStore interface {
+	// Load returns the checkpoint, ok is false when none was saved yet
+	Load() (blockNum uint32, ok bool, err error)
+	Save(blockNum uint32) error
+}
+
+// MemoryStore keeps the checkpoint in memory, it is lost on restart
+type MemoryStore struct {
+	blockNum uint32
+	ok       bool
+	mutex    sync.Mutex
+}
+
+func (s *MemoryStore) Load() (uint32, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.blockNum, s.ok, nil
+}
+
+func (s *MemoryStore) Save(blockNum uint32) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.blockNum = blockNum
+	s.ok = true
+	return nil
+}
+
+// FileStore keeps the checkpoint in a file, it is replaced atomically on every save
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load() (uint32, bool, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	num, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "invalid checkpoint in %s", s.path)
+	}
+	return uint32(num), true, nil
+}
+
+func (s *FileStore) Save(blockNum uint32) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(strconv.FormatUint(uint64(blockNum), 10)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
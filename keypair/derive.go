@@ -0,0 +1,151 @@
+package keypair
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+	"gxclient-go/types"
+	"strings"
+)
+
+// AccountKeys are the keys of the roles of an account derived from a brain key
+type AccountKeys struct {
+	BrainKey string
+	Sequence uint32
+	Owner    *types.PrivateKey
+	Active   *types.PrivateKey
+	Memo     *types.PrivateKey
+}
+
+// NormalizeBrainKey upper cases brainKey and separates its words by single spaces, as the wallets do
+// before deriving keys so that the same words always give the same keys
+func NormalizeBrainKey(brainKey string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(brainKey), " "))
+}
+
+// IsValidMnemonic checks that mnemonic is a BIP39 english mnemonic with a valid checksum,
+// the brain keys of GenerateKeyPair are
+func IsValidMnemonic(mnemonic string) bool {
+	mnemonic = strings.ToLower(strings.Join(strings.Fields(mnemonic), " "))
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return false
+	}
+	// IsMnemonicValid only checks the words, the checksum is checked when reading the entropy
+	_, err := bip39.EntropyFromMnemonic(mnemonic)
+	return err == nil
+}
+
+// DeriveAccountKeys derives the owner, active and memo keys of the first account of brainKey
+func DeriveAccountKeys(brainKey string) (*AccountKeys, error) {
+	return DeriveAccountKeysAt(brainKey, 0)
+}
+
+// DeriveAccountKeysAt derives the keys of the account at sequence of brainKey the way the cli_wallet does:
+// the owner key is derived from the normalized brain key and the sequence, the active key from the owner key
+// and the memo key from the active key, each with sequence 0
+func DeriveAccountKeysAt(brainKey string, sequence uint32) (*AccountKeys, error) {
+	normalized := NormalizeBrainKey(brainKey)
+	if normalized == "" {
+		return nil, errors.New("brain key is empty")
+	}
+
+	owner, err := types.NewPrivateKeyFromBrainKey(normalized, fmt.Sprint(sequence))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive owner key")
+	}
+	active, err := types.NewPrivateKeyFromBrainKey(owner.ToWIF(), "0")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive active key")
+	}
+	memo, err := types.NewPrivateKeyFromBrainKey(active.ToWIF(), "0")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive memo key")
+	}
+
+	return &AccountKeys{
+		BrainKey: normalized,
+		Sequence: sequence,
+		Owner:    owner,
+		Active:   active,
+		Memo:     memo,
+	}, nil
+}
+
+// AccountFinder finds the accounts referencing a public key, database.API is one
+type AccountFinder interface {
+	GetAccountsByPublicKey(publicKey string) ([]string, error)
+}
+
+// RegisteredKeys are the keys of a sequence referenced by accounts on chain
+type RegisteredKeys struct {
+	*AccountKeys
+	// Accounts are the ids of the accounts referencing any of the keys
+	Accounts []string
+	// Legacy is set for the key of GenerateKeyPair, derived from the brain key as given and used for every role
+	Legacy bool
+}
+
+// ScanAccountKeys derives the keys of the sequences of brainKey from 0 and returns those referenced by
+// accounts on chain, the scan stops after gapLimit sequences in a row without any account.
+// The key GenerateKeyPair derives from brainKey is checked first and returned with Legacy set when referenced.
+func ScanAccountKeys(finder AccountFinder, brainKey string, gapLimit uint32) ([]*RegisteredKeys, error) {
+	if gapLimit == 0 {
+		return nil, errors.New("gap limit must be positive")
+	}
+
+	var registered []*RegisteredKeys
+	// a normalized brain key gives the same key as the owner key of sequence 0
+	if brainKey != NormalizeBrainKey(brainKey) {
+		legacy, err := types.NewPrivateKeyFromBrainKey(brainKey, "0")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive legacy key")
+		}
+		accounts, err := findAccounts(finder, legacy)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get accounts of legacy key")
+		}
+		if len(accounts) > 0 {
+			keys := &AccountKeys{BrainKey: brainKey, Owner: legacy, Active: legacy, Memo: legacy}
+			registered = append(registered, &RegisteredKeys{AccountKeys: keys, Accounts: accounts, Legacy: true})
+		}
+	}
+
+	for sequence, gap := uint32(0), uint32(0); gap < gapLimit; sequence++ {
+		keys, err := DeriveAccountKeysAt(brainKey, sequence)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts, err := findAccounts(finder, keys.Owner, keys.Active, keys.Memo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get accounts of sequence %d", sequence)
+		}
+
+		if len(accounts) == 0 {
+			gap++
+			continue
+		}
+		gap = 0
+		registered = append(registered, &RegisteredKeys{AccountKeys: keys, Accounts: accounts})
+	}
+	return registered, nil
+}
+
+// findAccounts returns the ids of the accounts referencing any of keys, without duplicates
+func findAccounts(finder AccountFinder, keys ...*types.PrivateKey) ([]string, error) {
+	var accounts []string
+	seen := map[string]bool{}
+	for _, key := range keys {
+		ids, err := finder.GetAccountsByPublicKey(key.PublicKey().String())
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				accounts = append(accounts, id)
+			}
+		}
+	}
+	return accounts, nil
+}
@@ -0,0 +1,79 @@
+package gxclient_go
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"gxclient-go/sign"
+	"gxclient-go/types"
+	"time"
+)
+
+// SignMessage signs msg with the memo key of the client account and returns the armored signed message,
+// the meta holds the account name, the memo public key, the current time and a random nonce
+func (client *Client) SignMessage(msg string) (string, error) {
+	if client.memoPriKey == nil {
+		return "", errors.New("no memo key to sign the message")
+	}
+
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+
+	m := &sign.SignedMessage{
+		Message:   msg,
+		Account:   client.account.Name,
+		PublicKey: client.memoPriKey.PublicKey().String(),
+		Timestamp: time.Now().UTC().Truncate(time.Second),
+		Nonce:     binary.BigEndian.Uint64(nonce[:]),
+	}
+	if err := sign.SignMessage(m, client.memoPriKey.ToECDSA()); err != nil {
+		return "", err
+	}
+	return m.String(), nil
+}
+
+// VerifyMessage checks an armored signed message: the signature must be of the key in its meta, and that key
+// must be the memo key or one of the active keys of the account fetched from the node.
+// With maxAge > 0 messages whose timestamp is older than maxAge, or in the future by more than maxAge, are rejected.
+// Rejecting replayed nonces is left to the caller.
+func (client *Client) VerifyMessage(armored string, maxAge time.Duration) (*sign.SignedMessage, error) {
+	m, err := sign.ParseSignedMessage(armored)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := types.NewPublicKeyFromString(m.PublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key: %v", m.PublicKey)
+	}
+	if err := sign.VerifyMessage(pub.ToECDSA(), m.Content(), m.Signature); err != nil {
+		return nil, err
+	}
+
+	if maxAge > 0 {
+		age := time.Since(m.Timestamp)
+		if m.Timestamp.IsZero() || age > maxAge || age < -maxAge {
+			return nil, errors.Errorf("message timestamp %v is out of the accepted range", m.Timestamp)
+		}
+	}
+
+	account, err := client.Database.GetAccount(m.Account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get account %s", m.Account)
+	}
+	if account == nil {
+		return nil, errors.Errorf("account %s not found", m.Account)
+	}
+
+	if !account.Options.MemoKey.IsNul() && account.Options.MemoKey.Equal(pub) {
+		return m, nil
+	}
+	for key := range account.Active.KeyAuths {
+		if key != nil && !key.IsNul() && key.Equal(pub) {
+			return m, nil
+		}
+	}
+	return nil, errors.Errorf("key %s is neither the memo key nor an active key of account %s", m.PublicKey, m.Account)
+}
@@ -1,20 +1,86 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"gxclient-go/offline"
+	"os"
+	"strconv"
+	"strings"
 )
 
+// assetFlags collects -asset id:symbol:precision
+type assetFlags map[string]offline.Asset
+
+func (a assetFlags) String() string {
+	return ""
+}
+
+func (a assetFlags) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return fmt.Errorf("expecting id:symbol:precision, got %s", value)
+	}
+	precision, err := strconv.ParseUint(parts[2], 10, 8)
+	if err != nil {
+		return fmt.Errorf("invalid precision %s", parts[2])
+	}
+	a[parts[0]] = offline.Asset{Symbol: parts[1], Precision: uint8(precision)}
+	return nil
+}
+
 func main() {
+	assets := assetFlags{}
 	activePriWif := flag.String("key", "", "wif of active private key")
 	unSignedHex := flag.String("txHex", "", "hex of unSigned transaction")
 	chainId := flag.String("chainId", "", "chainId")
+	yes := flag.Bool("yes", false, "sign without asking for confirmation")
+	jsonOutput := flag.Bool("json", false, "print the review and the signature as json")
+	flag.Var(assets, "asset", "id:symbol:precision of an asset to show its amounts, can be repeated")
 	flag.Parse()
 
+	review, err := offline.ReviewTransaction(*unSignedHex, *chainId, assets)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "review failed :", err)
+		os.Exit(1)
+	}
+
+	// the review goes to stderr so that stdout only holds the signature or the json output
+	if !*jsonOutput || !*yes {
+		fmt.Fprint(os.Stderr, review)
+	}
+	if !*yes && !confirm() {
+		fmt.Fprintln(os.Stderr, "aborted")
+		os.Exit(1)
+	}
+
 	sig, err := offline.OfflineSign(*activePriWif, *unSignedHex, *chainId)
 	if err != nil {
-		fmt.Println("sign failed :", err)
+		fmt.Fprintln(os.Stderr, "sign failed :", err)
+		os.Exit(1)
+	}
+
+	if *jsonOutput {
+		out, err := json.MarshalIndent(map[string]interface{}{
+			"review":    review,
+			"signature": sig,
+		}, "", "  ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "json failed :", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+		return
 	}
 	fmt.Println(sig)
 }
+
+// confirm asks the operator on the terminal, anything but y or yes declines
+func confirm() bool {
+	fmt.Fprint(os.Stderr, "sign this transaction? [y/N]: ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,258 @@
+package offline
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"gxclient-go/transaction"
+	"gxclient-go/types"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Asset is used to show the amounts of an asset with its symbol and precision
+type Asset struct {
+	Symbol    string
+	Precision uint8
+}
+
+// CoreAssetID is GXC, its amounts are always shown with its symbol
+const CoreAssetID = "1.3.1"
+
+var coreAsset = Asset{Symbol: "GXC", Precision: 5}
+
+var opNames = map[types.OpType]string{
+	types.TransferOpType:                "transfer",
+	types.AccountCreateOpType:           "account_create",
+	types.AccountUpdateOpType:           "account_update",
+	types.AccountUpgradeOpType:          "account_upgrade",
+	types.AssetCreateOpType:             "asset_create",
+	types.AssetUpdateOpType:             "asset_update",
+	types.AssetIssueOpType:              "asset_issue",
+	types.AssetReserveOpType:            "asset_reserve",
+	types.AssetFundFeePoolOpType:        "asset_fund_fee_pool",
+	types.WitnessCreateOpType:           "witness_create",
+	types.WitnessUpdateOpType:           "witness_update",
+	types.ProposalCreateOpType:          "proposal_create",
+	types.ProposalUpdateOpType:          "proposal_update",
+	types.ProposalDeleteOpType:          "proposal_delete",
+	types.CommitteeMemberCreateOpType:   "committee_member_create",
+	types.CommitteeMemberUpdateOpType:   "committee_member_update",
+	types.VestingBalanceCreateOpType:    "vesting_balance_create",
+	types.VestingBalanceWithdrawOpType:  "vesting_balance_withdraw",
+	types.BalanceLockOpType:             "balance_lock",
+	types.BalanceUnlockOpType:           "balance_unlock",
+	types.ProxyTransferOpType:           "proxy_transfer",
+	types.CreateContractOpType:          "contract_deploy",
+	types.CallContractOpType:            "call_contract",
+	types.UpdateContractOpType:          "contract_update",
+	types.TrustNodePledgeWithdrawOpType: "trust_node_pledge_withdraw",
+	types.InlineTransferOpType:          "inline_transfer",
+	types.StakingCreateOpType:           "staking_create",
+	types.StakingUpdateOpType:           "staking_update",
+	types.StakingClaimOpType:            "staking_claim",
+}
+
+// Review is the content of a transaction to be signed, for the operator to check before signing
+type Review struct {
+	ChainID        string             `json:"chain_id"`
+	RefBlockNum    uint16             `json:"ref_block_num"`
+	RefBlockPrefix uint32             `json:"ref_block_prefix"`
+	Expiration     time.Time          `json:"expiration"`
+	Expired        bool               `json:"expired"`
+	Operations     []*OperationReview `json:"operations"`
+}
+
+// OperationReview summarizes an operation, From, To and Amount are set for the operations moving funds
+type OperationReview struct {
+	Type    types.OpType       `json:"type"`
+	Name    string             `json:"name"`
+	Fee     string             `json:"fee,omitempty"`
+	From    string             `json:"from,omitempty"`
+	To      string             `json:"to,omitempty"`
+	Amount  string             `json:"amount,omitempty"`
+	HasMemo bool               `json:"has_memo"`
+	Method  string             `json:"method,omitempty"`
+	Nested  []*OperationReview `json:"proposed_operations,omitempty"`
+	// Operation is the decoded operation with all its fields
+	Operation types.Operation `json:"operation"`
+}
+
+// ReviewTransaction decodes the transaction signed by OfflineSign, amounts of the assets other than GXC are
+// shown in their smallest unit unless their symbol and precision are given in assets, keyed by asset id.
+// It fails if the transaction cannot be decoded entirely, so that nothing is signed without being shown.
+func ReviewTransaction(unSignedHex, chainId string, assets map[string]Asset) (*Review, error) {
+	if _, err := hex.DecodeString(chainId); err != nil || len(chainId) != 64 {
+		return nil, errors.Errorf("invalid chain ID: %v", chainId)
+	}
+
+	tx, err := types.NewTransactionFromHex(unSignedHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction")
+	}
+	if len(tx.Signatures) != 0 {
+		return nil, errors.New("transaction is already signed")
+	}
+
+	// OfflineSign signs the hex without its last byte, the empty signatures
+	var b bytes.Buffer
+	if err := transaction.NewEncoder(&b).Encode(tx); err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(b.Bytes())+"00" != strings.ToLower(unSignedHex) {
+		return nil, errors.New("transaction hex does not match its decoded content")
+	}
+
+	if assets == nil {
+		assets = map[string]Asset{}
+	}
+	review := &Review{
+		ChainID:        chainId,
+		RefBlockNum:    tx.RefBlockNum,
+		RefBlockPrefix: tx.RefBlockPrefix,
+		Expiration:     *tx.Expiration.Time,
+		Expired:        tx.Expiration.Before(time.Now()),
+	}
+	for _, op := range tx.Operations {
+		review.Operations = append(review.Operations, reviewOperation(op, assets))
+	}
+	return review, nil
+}
+
+func reviewOperation(op types.Operation, assets map[string]Asset) *OperationReview {
+	name, ok := opNames[op.Type()]
+	if !ok {
+		name = fmt.Sprintf("operation %d", op.Type())
+	}
+	r := &OperationReview{Type: op.Type(), Name: name, Operation: op}
+
+	switch op := op.(type) {
+	case *types.TransferOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To, r.Amount = op.From.String(), op.To.String(), formatAmount(op.Amount, assets)
+		r.HasMemo = op.Memo != nil && op.Memo.Message.Length() > 0
+	case *types.InlineTransferOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To, r.Amount = op.From.String(), op.To.String(), formatAmount(op.Amount, assets)
+		r.HasMemo = op.Memo != ""
+	case *types.AssetIssueOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To, r.Amount = op.Issuer.String(), op.IssueToAccount.String(), formatAmount(op.AssetToIssue, assets)
+		r.HasMemo = op.Memo != nil && op.Memo.Message.Length() > 0
+	case *types.AssetReserveOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.Amount = op.Payer.String(), formatAmount(op.AmountToReserve, assets)
+	case *types.AssetFundFeePoolOperation:
+		// the fee pool is funded in the core asset, To is the asset of the pool
+		r.Fee = formatAmount(op.Fee, assets)
+		pool := types.AssetAmount{Amount: uint64(op.Amount), AssetID: types.MustParseObjectID(CoreAssetID)}
+		r.From, r.To, r.Amount = op.FromAccount.String(), op.AssetID.String(), formatAmount(pool, assets)
+	case *types.StakingCreateOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To, r.Amount = op.Owner.String(), op.TrustNode.String(), formatAmount(op.Amount, assets)
+	case *types.StakingUpdateOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To = op.Owner.String(), op.TrustNode.String()
+	case *types.StakingClaimOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From = op.Owner.String()
+	case *types.CallContractOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To, r.Method = op.Account.String(), op.ContractId.String(), op.MethodName
+		if op.Amount != nil {
+			r.Amount = formatAmount(*op.Amount, assets)
+		}
+	case *types.CreateContractOperation:
+		// the contract has no id before it is deployed, To is its name
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To = op.Account.String(), op.Name
+	case *types.UpdateContractOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From, r.To = op.Owner.String(), op.Contract.String()
+	case *types.VestingBalanceWithdrawOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.To, r.Amount = op.Owner.String(), formatAmount(op.Amount, assets)
+	case *types.AccountCreateOperation:
+		if op.Fee != nil {
+			r.Fee = formatAmount(*op.Fee, assets)
+		}
+		r.From = op.Registrar.String()
+	case *types.AccountUpdateOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From = op.Account.String()
+	case *types.AccountUpgradeOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From = op.AccountToUpgrade.String()
+	case *types.ProposalCreateOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From = op.FeePayingAccount.String()
+		for _, wrapper := range op.ProposedOps {
+			r.Nested = append(r.Nested, reviewOperation(wrapper.Op, assets))
+		}
+	case *types.ProposalUpdateOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From = op.FeePayingAccount.String()
+	case *types.ProposalDeleteOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From = op.FeePayingAccount.String()
+	case *types.BalanceUnlockOperation:
+		r.Fee = formatAmount(op.Fee, assets)
+		r.From = op.Account.String()
+	}
+	return r
+}
+
+// formatAmount shows amount with the symbol and precision of its asset when known
+func formatAmount(amount types.AssetAmount, assets map[string]Asset) string {
+	assetID := amount.AssetID.String()
+	asset, ok := assets[assetID]
+	if !ok && assetID == CoreAssetID {
+		asset, ok = coreAsset, true
+	}
+	if !ok {
+		return fmt.Sprintf("%d %s", amount.Amount, assetID)
+	}
+
+	value := new(big.Rat).SetFrac(new(big.Int).SetUint64(amount.Amount),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(asset.Precision)), nil))
+	return fmt.Sprintf("%s %s", value.FloatString(int(asset.Precision)), asset.Symbol)
+}
+
+// String renders the review for the operator
+func (r *Review) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "chain id:   %s\n", r.ChainID)
+	expiration := r.Expiration.UTC().Format("2006-01-02T15:04:05Z")
+	if r.Expired {
+		expiration += " (EXPIRED)"
+	}
+	fmt.Fprintf(&b, "expiration: %s\n", expiration)
+	fmt.Fprintf(&b, "ref block:  %d / %d\n", r.RefBlockNum, r.RefBlockPrefix)
+	fmt.Fprintf(&b, "operations: %d\n", len(r.Operations))
+	for i, op := range r.Operations {
+		writeOperation(&b, op, fmt.Sprintf("%d", i+1), "  ")
+	}
+	return b.String()
+}
+
+func writeOperation(b *strings.Builder, op *OperationReview, number, indent string) {
+	fmt.Fprintf(b, "%s#%s %s\n", indent, number, op.Name)
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(b, "%s    %-8s %s\n", indent, name+":", value)
+		}
+	}
+	field("from", op.From)
+	field("to", op.To)
+	field("amount", op.Amount)
+	field("method", op.Method)
+	field("fee", op.Fee)
+	if op.HasMemo {
+		field("memo", "yes")
+	}
+	for i, nested := range op.Nested {
+		writeOperation(b, nested, fmt.Sprintf("%s.%d", number, i+1), indent+"    ")
+	}
+}
@@ -0,0 +1,105 @@
+package gxclient_go
+
+import (
+	"github.com/pkg/errors"
+	"gxclient-go/types"
+	"time"
+)
+
+// ApprovalType is the authority an approval of a proposal is given with
+type ApprovalType int
+
+const (
+	// ActiveApproval approves with the active authority of the client account
+	ActiveApproval ApprovalType = iota
+	// OwnerApproval approves with the owner authority of the client account,
+	// the transaction is signed by the signer of the client so its key must satisfy the owner authority
+	OwnerApproval
+	// KeyApproval approves with the key of the signer of the client itself
+	KeyApproval
+)
+
+// ProposeTransaction proposes ops, paid by the client account, the proposal can be approved until expiration.
+// A reviewPeriod > 0 sets the review period of the proposal, required when the ops need the committee account.
+// The fees of ops are set in feeSymbol before they are proposed.
+func (client *Client) ProposeTransaction(ops []types.Operation, expiration time.Time, reviewPeriod time.Duration, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	if len(ops) == 0 {
+		return nil, errors.New("no operation to propose")
+	}
+	if reviewPeriod < 0 || reviewPeriod > time.Duration(^uint32(0))*time.Second {
+		return nil, errors.Errorf("invalid review period %v", reviewPeriod)
+	}
+
+	fee, err := client.Database.GetAsset(feeSymbol)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := client.Database.GetRequiredFee(ops, fee.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(fees) != len(ops) {
+		return nil, errors.Errorf("got %d fees for %d operations", len(fees), len(ops))
+	}
+	for i, op := range ops {
+		if err := types.SetOperationFee(op, fees[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var reviewPeriodSeconds *uint32
+	if reviewPeriod > 0 {
+		seconds := uint32(reviewPeriod / time.Second)
+		reviewPeriodSeconds = &seconds
+	}
+	expiration = expiration.UTC()
+	op := types.NewProposalCreateOperation(types.MustParseObjectID(client.account.ID.String()), ops,
+		types.Time{Time: &expiration}, reviewPeriodSeconds, types.AssetAmount{AssetID: fee.ID})
+
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
+
+// ApproveProposal adds the approval of the given type of the client to the proposal
+func (client *Client) ApproveProposal(proposalID string, approval ApprovalType, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	return client.updateProposal(proposalID, approval, true, feeSymbol, broadcast)
+}
+
+// RevokeApproval removes the approval of the given type of the client from the proposal
+func (client *Client) RevokeApproval(proposalID string, approval ApprovalType, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	return client.updateProposal(proposalID, approval, false, feeSymbol, broadcast)
+}
+
+func (client *Client) updateProposal(proposalID string, approval ApprovalType, add bool, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	proposal, err := types.ParseObjectID(proposalID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid proposal id %s", proposalID)
+	}
+	account := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewProposalUpdateOperation(account, proposal, types.AssetAmount{})
+
+	switch approval {
+	case ActiveApproval:
+		if add {
+			op.ActiveApprovalsToAdd = types.ObjectIDs{account}
+		} else {
+			op.ActiveApprovalsToRemove = types.ObjectIDs{account}
+		}
+	case OwnerApproval:
+		if add {
+			op.OwnerApprovalsToAdd = types.ObjectIDs{account}
+		} else {
+			op.OwnerApprovalsToRemove = types.ObjectIDs{account}
+		}
+	case KeyApproval:
+		key := *client.signer.PublicKey()
+		if add {
+			op.KeyApprovalsToAdd = types.PublicKeys{key}
+		} else {
+			op.KeyApprovalsToRemove = types.PublicKeys{key}
+		}
+	default:
+		return nil, errors.Errorf("unknown approval type %d", approval)
+	}
+
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 )
@@ -15,7 +16,50 @@ type Caller interface {
 	Connect() error
 }
 
+// ContextCaller is implemented by the transports able to give up a call, the transports of this module all are
+type ContextCaller interface {
+	// CallContext is like Call but gives up waiting for the reply once ctx is done
+	CallContext(ctx context.Context, api APIID, method string, args []interface{}, reply interface{}) error
+}
+
+// CallbackUnsetter is implemented by the transports keeping the callbacks set with SetCallback,
+// to register them again on reconnect
+type CallbackUnsetter interface {
+	// SetCallbackID is like SetCallback and returns the id of the callback for UnsetCallback
+	SetCallbackID(api APIID, method string, callback func(raw json.RawMessage)) (uint64, error)
+	// UnsetCallback forgets a callback, it is no longer called nor registered again on reconnect
+	UnsetCallback(id uint64)
+}
+
+// CallContext calls through the CallContext of caller when it is a ContextCaller, otherwise through its Call,
+// which ctx can then only prevent from starting
+func CallContext(ctx context.Context, caller Caller, api APIID, method string, args []interface{}, reply interface{}) error {
+	if c, ok := caller.(ContextCaller); ok {
+		return c.CallContext(ctx, api, method, args, reply)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return caller.Call(api, method, args, reply)
+}
+
 type CallCloser interface {
 	Caller
 	io.Closer
 }
+
+// BatchItem is a single call of a batch, Reply is filled with the result and Error with the error of the call
+type BatchItem struct {
+	API    APIID
+	Method string
+	Args   []interface{}
+	Reply  interface{}
+	Error  error
+}
+
+// BatchCaller is implemented by transports able to send several calls in one round trip
+type BatchCaller interface {
+	// CallBatch sends all items at once, the returned error only reports a failure of the whole batch
+	CallBatch(items []BatchItem) error
+	CallBatchContext(ctx context.Context, items []BatchItem) error
+}
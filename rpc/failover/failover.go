@@ -0,0 +1,654 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"gxclient-go/rpc"
+	"gxclient-go/rpc/http"
+	"gxclient-go/rpc/websocket"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// login_api always has the id 1 on websocket nodes
+const loginAPIID rpc.APIID = "1"
+
+var ErrNoHealthyNode = errors.New("no healthy node available")
+
+type Options struct {
+	// ProbeInterval is the delay between two health checks of every node
+	ProbeInterval time.Duration
+	// ProbeTimeout bounds a single health check
+	ProbeTimeout time.Duration
+	// MaxBlockLag is how many blocks a node may be behind the best head block and still be used
+	MaxBlockLag uint32
+	// StallTimeout marks a node unhealthy when its head block has not advanced for that long
+	StallTimeout time.Duration
+	// OnSwitch is called whenever calls are routed to another node, from is empty for the first node
+	OnSwitch func(from, to string)
+}
+
+func DefaultOptions() Options {
+	return Options{
+		ProbeInterval: 10 * time.Second,
+		ProbeTimeout:  3 * time.Second,
+		MaxBlockLag:   3,
+		StallTimeout:  30 * time.Second,
+	}
+}
+
+// NodeStatus is the health of a node as seen by its last probe
+type NodeStatus struct {
+	URL       string
+	Healthy   bool
+	Latency   time.Duration
+	HeadBlock uint32
+	Err       error
+}
+
+// Transport routes calls to the healthiest of several nodes, websocket or http,
+// and fails over to the next one when a node errors or stops producing blocks.
+// APIs are addressed by name ("database", "history", "network_broadcast"),
+// websocket nodes get them resolved through login_api.
+type Transport struct {
+	options Options
+	chainID string
+
+	nodes         []*node
+	current       *node
+	subscriptions []*subscription
+	callbackID    uint64
+
+	closing bool
+	done    chan struct{}
+	mutex   sync.Mutex
+}
+
+type node struct {
+	url string
+
+	// guarded by the transport mutex
+	conn        *conn
+	chainID     string
+	mismatch    bool // reports another chain, never used
+	healthy     bool
+	latency     time.Duration
+	headBlock   uint32
+	headChanged time.Time
+	err         error
+}
+
+// conn is a connection to a node, api ids resolved on websocket nodes are only valid for it
+type conn struct {
+	cc     rpc.CallCloser
+	named  bool
+	apiIDs map[rpc.APIID]rpc.APIID
+	mutex  sync.Mutex
+}
+
+type subscription struct {
+	id       uint64
+	api      rpc.APIID
+	method   string
+	callback func(raw json.RawMessage)
+	conn     *conn  // the connection notices are accepted from
+	connID   uint64 // the id of the callback on conn, 0 when conn cannot unset it
+}
+
+func NewTransport(urls []string) (*Transport, error) {
+	return NewTransportWithOptions(urls, DefaultOptions())
+}
+
+// NewTransportWithOptions probes every node and fails if none is reachable
+// or if the nodes do not all report the same chain id
+func NewTransportWithOptions(urls []string, options Options) (*Transport, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no node url given")
+	}
+
+	t := &Transport{
+		options: options,
+		done:    make(chan struct{}),
+	}
+	for _, url := range urls {
+		t.nodes = append(t.nodes, &node{url: url})
+	}
+
+	t.probeAll()
+
+	var lastErr error
+	for _, n := range t.nodes {
+		if n.chainID == "" {
+			lastErr = n.err
+			continue
+		}
+		if t.chainID == "" {
+			t.chainID = n.chainID
+			continue
+		}
+		if n.chainID != t.chainID {
+			t.closeNodes()
+			return nil, errors.Errorf("chain id mismatch: %s reports %s, expected %s", n.url, n.chainID, t.chainID)
+		}
+	}
+	if t.chainID == "" {
+		t.closeNodes()
+		return nil, errors.Wrap(lastErr, "no node reachable")
+	}
+
+	t.mutex.Lock()
+	t.selectNode(nil)
+	t.mutex.Unlock()
+
+	go t.monitor()
+	return t, nil
+}
+
+// ChainID returns the chain id all nodes were verified against
+func (t *Transport) ChainID() string {
+	return t.chainID
+}
+
+// Current returns the url of the node calls are routed to
+func (t *Transport) Current() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.current == nil {
+		return ""
+	}
+	return t.current.url
+}
+
+// Nodes returns the status of every node
+func (t *Transport) Nodes() []NodeStatus {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	status := make([]NodeStatus, len(t.nodes))
+	for i, n := range t.nodes {
+		status[i] = NodeStatus{
+			URL:       n.url,
+			Healthy:   n.healthy && !n.mismatch,
+			Latency:   n.latency,
+			HeadBlock: n.headBlock,
+			Err:       n.err,
+		}
+	}
+	return status
+}
+
+func (t *Transport) Connect() error {
+	return nil
+}
+
+func (t *Transport) Call(api rpc.APIID, method string, args []interface{}, reply interface{}) error {
+	return t.CallContext(context.Background(), api, method, args, reply)
+}
+
+func (t *Transport) CallContext(ctx context.Context, api rpc.APIID, method string, args []interface{}, reply interface{}) error {
+	return t.do(ctx, func(c *conn) error {
+		return c.call(ctx, api, method, args, reply)
+	})
+}
+
+func (t *Transport) CallBatch(items []rpc.BatchItem) error {
+	return t.CallBatchContext(context.Background(), items)
+}
+
+// CallBatchContext sends the batch in one round trip when the node supports it,
+// otherwise the items are called one after the other on the same node
+func (t *Transport) CallBatchContext(ctx context.Context, items []rpc.BatchItem) error {
+	return t.do(ctx, func(c *conn) error {
+		return c.callBatch(ctx, items)
+	})
+}
+
+// SetCallback subscribes on the current node and again on every node calls are switched to,
+// notices of a node that is no longer used are dropped
+func (t *Transport) SetCallback(api rpc.APIID, method string, callback func(raw json.RawMessage)) error {
+	_, err := t.SetCallbackID(api, method, callback)
+	return err
+}
+
+// SetCallbackID is like SetCallback and returns the id of the callback for UnsetCallback
+func (t *Transport) SetCallbackID(api rpc.APIID, method string, callback func(raw json.RawMessage)) (uint64, error) {
+	t.mutex.Lock()
+	t.callbackID++
+	s := &subscription{id: t.callbackID, api: api, method: method, callback: callback}
+	t.subscriptions = append(t.subscriptions, s)
+	t.mutex.Unlock()
+
+	err := t.do(context.Background(), func(c *conn) error {
+		return t.subscribe(c, s)
+	})
+	return s.id, err
+}
+
+// UnsetCallback forgets the callback id, it is unset on the node connection as well
+func (t *Transport) UnsetCallback(id uint64) {
+	t.mutex.Lock()
+	var removed *subscription
+	for i, s := range t.subscriptions {
+		if s.id == id {
+			removed = s
+			t.subscriptions = append(t.subscriptions[:i], t.subscriptions[i+1:]...)
+			break
+		}
+	}
+	var c *conn
+	var connID uint64
+	if removed != nil {
+		c, connID = removed.conn, removed.connID
+		removed.conn, removed.connID = nil, 0
+	}
+	t.mutex.Unlock()
+
+	c.unsetCallback(connID)
+}
+
+// Close stops the health checks and closes every node connection
+func (t *Transport) Close() error {
+	t.mutex.Lock()
+	if t.closing {
+		t.mutex.Unlock()
+		return rpc.ErrShutdown
+	}
+	t.closing = true
+	close(t.done)
+	t.mutex.Unlock()
+
+	t.closeNodes()
+	return nil
+}
+
+// do runs fn on the current node, failing over to the next healthiest node on connection errors.
+// Errors returned by the node itself are not retried.
+func (t *Transport) do(ctx context.Context, fn func(c *conn) error) error {
+	tried := map[*node]bool{}
+	var lastErr error
+	for {
+		t.mutex.Lock()
+		if t.closing {
+			t.mutex.Unlock()
+			return rpc.ErrShutdown
+		}
+		n := t.current
+		if n == nil || tried[n] || n.conn == nil {
+			n = t.selectNode(tried)
+		}
+		if n == nil {
+			t.mutex.Unlock()
+			if lastErr != nil {
+				return errors.Wrapf(ErrNoHealthyNode, "last error: %v", lastErr)
+			}
+			return ErrNoHealthyNode
+		}
+		c := n.conn
+		t.mutex.Unlock()
+
+		err := fn(c)
+		if err == nil || !isConnectionError(ctx, err) {
+			return err
+		}
+
+		log.Printf("failover: node %s failed: %v", n.url, err)
+		tried[n] = true
+		lastErr = err
+		t.markDown(n, c, err)
+	}
+}
+
+// isConnectionError tells whether err means the node is unusable: dial, read and write errors, timeouts,
+// lost connections and http status errors. Other errors, such as a rejected call or a reply that does not
+// decode, are errors of the call and are returned to the caller as they are
+func isConnectionError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, rpc.ErrShutdown) || errors.Is(err, websocket.ErrNotConnected) {
+		return true
+	}
+	var netErr net.Error
+	var statusErr *http.StatusError
+	return errors.As(err, &netErr) || errors.As(err, &statusErr)
+}
+
+// selectNode routes calls to the lowest latency node among the healthy ones
+// whose head block is close to the best head block, it must be called with the mutex held
+func (t *Transport) selectNode(exclude map[*node]bool) *node {
+	var candidates []*node
+	var bestHead uint32
+	for _, n := range t.nodes {
+		if exclude[n] || !t.usable(n) {
+			continue
+		}
+		candidates = append(candidates, n)
+		if n.headBlock > bestHead {
+			bestHead = n.headBlock
+		}
+	}
+
+	var best *node
+	for _, n := range candidates {
+		if n.headBlock+t.options.MaxBlockLag < bestHead {
+			continue
+		}
+		if best == nil || n.latency < best.latency {
+			best = n
+		}
+	}
+
+	// stay on the current node while it is fresh and not much slower, to avoid flapping
+	if cur := t.current; cur != nil && best != nil && cur != best && !exclude[cur] && t.usable(cur) &&
+		cur.headBlock+t.options.MaxBlockLag >= bestHead && cur.latency <= 2*best.latency {
+		best = cur
+	}
+
+	if best != t.current {
+		from := ""
+		if t.current != nil {
+			from = t.current.url
+		}
+		t.current = best
+		if best != nil {
+			if t.options.OnSwitch != nil {
+				go t.options.OnSwitch(from, best.url)
+			}
+			if len(t.subscriptions) > 0 {
+				go t.resubscribe(best.conn)
+			}
+		}
+	}
+	return best
+}
+
+func (t *Transport) usable(n *node) bool {
+	return n.conn != nil && n.healthy && !n.mismatch && time.Since(n.headChanged) < t.options.StallTimeout
+}
+
+// markDown takes the node out of rotation until its next successful probe
+func (t *Transport) markDown(n *node, c *conn, err error) {
+	t.mutex.Lock()
+	if n.conn != c {
+		t.mutex.Unlock()
+		return
+	}
+	n.healthy = false
+	n.err = err
+	n.conn = nil
+	t.mutex.Unlock()
+
+	c.cc.Close()
+}
+
+func (t *Transport) monitor() {
+	ticker := time.NewTicker(t.options.ProbeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+
+		t.probeAll()
+		t.mutex.Lock()
+		if !t.closing {
+			t.selectNode(nil)
+		}
+		t.mutex.Unlock()
+	}
+}
+
+func (t *Transport) probeAll() {
+	var wg sync.WaitGroup
+	for _, n := range t.nodes {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			t.probe(n)
+		}(n)
+	}
+	wg.Wait()
+}
+
+// probe connects the node if needed, checks its chain id once and measures
+// the latency of get_dynamic_global_properties
+func (t *Transport) probe(n *node) {
+	t.mutex.Lock()
+	if t.closing || n.mismatch {
+		t.mutex.Unlock()
+		return
+	}
+	c := n.conn
+	chainID := n.chainID
+	t.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.options.ProbeTimeout)
+	defer cancel()
+
+	if c == nil {
+		var err error
+		c, err = dial(n.url)
+		if err != nil {
+			t.mutex.Lock()
+			n.healthy = false
+			n.err = err
+			t.mutex.Unlock()
+			return
+		}
+		t.mutex.Lock()
+		if t.closing {
+			t.mutex.Unlock()
+			c.cc.Close()
+			return
+		}
+		n.conn = c
+		t.mutex.Unlock()
+	}
+
+	if chainID == "" {
+		if err := c.call(ctx, "database", "get_chain_id", rpc.EmptyParams, &chainID); err != nil {
+			t.markDown(n, c, err)
+			return
+		}
+		t.mutex.Lock()
+		n.chainID = chainID
+		if t.chainID != "" && chainID != t.chainID {
+			n.mismatch = true
+			n.err = errors.Errorf("chain id mismatch: %s reports %s, expected %s", n.url, chainID, t.chainID)
+			log.Printf("failover: %v", n.err)
+		}
+		t.mutex.Unlock()
+		if n.mismatch {
+			return
+		}
+	}
+
+	var props struct {
+		HeadBlockNumber uint32 `json:"head_block_number"`
+	}
+	start := time.Now()
+	if err := c.call(ctx, "database", "get_dynamic_global_properties", rpc.EmptyParams, &props); err != nil {
+		t.markDown(n, c, err)
+		return
+	}
+	latency := time.Since(start)
+
+	t.mutex.Lock()
+	if props.HeadBlockNumber != n.headBlock || n.headChanged.IsZero() {
+		n.headBlock = props.HeadBlockNumber
+		n.headChanged = time.Now()
+	}
+	n.latency = latency
+	n.healthy = true
+	n.err = nil
+	if time.Since(n.headChanged) >= t.options.StallTimeout {
+		n.err = errors.Errorf("head block %d has not advanced for %s", n.headBlock, time.Since(n.headChanged))
+	}
+	t.mutex.Unlock()
+}
+
+func (t *Transport) subscribe(c *conn, s *subscription) error {
+	t.mutex.Lock()
+	if s.conn == c {
+		t.mutex.Unlock()
+		return nil
+	}
+	old, oldID := s.conn, s.connID
+	s.conn, s.connID = c, 0
+	t.mutex.Unlock()
+
+	// the previous connection no longer delivers the notices
+	old.unsetCallback(oldID)
+
+	id, err := c.setCallback(s.api, s.method, func(raw json.RawMessage) {
+		t.mutex.Lock()
+		active := s.conn == c
+		t.mutex.Unlock()
+		if active {
+			s.callback(raw)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	active := s.conn == c
+	if active {
+		s.connID = id
+	}
+	t.mutex.Unlock()
+	if !active {
+		// unset or moved on meanwhile
+		c.unsetCallback(id)
+	}
+	return nil
+}
+
+// resubscribe moves every subscription to c
+func (t *Transport) resubscribe(c *conn) {
+	t.mutex.Lock()
+	subscriptions := append([]*subscription(nil), t.subscriptions...)
+	t.mutex.Unlock()
+
+	for _, s := range subscriptions {
+		if err := t.subscribe(c, s); err != nil {
+			log.Printf("failover: failed to resubscribe %s: %v", s.method, err)
+		}
+	}
+}
+
+func (t *Transport) closeNodes() {
+	t.mutex.Lock()
+	var conns []*conn
+	for _, n := range t.nodes {
+		if n.conn != nil {
+			conns = append(conns, n.conn)
+			n.conn = nil
+		}
+	}
+	t.mutex.Unlock()
+
+	for _, c := range conns {
+		c.cc.Close()
+	}
+}
+
+func dial(url string) (*conn, error) {
+	if strings.HasPrefix(url, "http") {
+		return &conn{cc: http.NewTransport(url), named: true}, nil
+	}
+
+	// the failover transport redials itself, a dead connection must fail fast
+	options := websocket.DefaultOptions()
+	options.Reconnect = false
+	cc, err := websocket.NewTransportWithOptions(url, options)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{cc: cc, apiIDs: map[rpc.APIID]rpc.APIID{}}, nil
+}
+
+func (c *conn) call(ctx context.Context, api rpc.APIID, method string, args []interface{}, reply interface{}) error {
+	id, err := c.resolve(ctx, api)
+	if err != nil {
+		return err
+	}
+	return rpc.CallContext(ctx, c.cc, id, method, args, reply)
+}
+
+func (c *conn) callBatch(ctx context.Context, items []rpc.BatchItem) error {
+	if batcher, ok := c.cc.(rpc.BatchCaller); ok && c.named {
+		return batcher.CallBatchContext(ctx, items)
+	}
+
+	for i := range items {
+		err := c.call(ctx, items[i].API, items[i].Method, items[i].Args, items[i].Reply)
+		if err != nil && isConnectionError(ctx, err) {
+			return err
+		}
+		items[i].Error = err
+	}
+	return nil
+}
+
+// setCallback returns the id of the callback on the node transport, 0 when it cannot unset callbacks
+func (c *conn) setCallback(api rpc.APIID, method string, callback func(raw json.RawMessage)) (uint64, error) {
+	id, err := c.resolve(context.Background(), api)
+	if err != nil {
+		return 0, err
+	}
+	if unsetter, ok := c.cc.(rpc.CallbackUnsetter); ok {
+		return unsetter.SetCallbackID(id, method, callback)
+	}
+	return 0, c.cc.SetCallback(id, method, callback)
+}
+
+// unsetCallback unsets a callback set by setCallback, c may be nil
+func (c *conn) unsetCallback(id uint64) {
+	if c == nil || id == 0 {
+		return
+	}
+	if unsetter, ok := c.cc.(rpc.CallbackUnsetter); ok {
+		unsetter.UnsetCallback(id)
+	}
+}
+
+// resolve turns an api name into the id handed out by login_api on websocket nodes,
+// http nodes take the name as is
+func (c *conn) resolve(ctx context.Context, api rpc.APIID) (rpc.APIID, error) {
+	if c.named {
+		return api, nil
+	}
+	if _, err := strconv.ParseUint(string(api), 10, 64); err == nil {
+		return api, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if id, ok := c.apiIDs[api]; ok {
+		return id, nil
+	}
+
+	if len(c.apiIDs) == 0 {
+		var ok bool
+		if err := rpc.CallContext(ctx, c.cc, loginAPIID, "login", []interface{}{"", ""}, &ok); err != nil {
+			return "", errors.Wrap(err, "failed to login")
+		}
+	}
+	var id uint64
+	if err := rpc.CallContext(ctx, c.cc, loginAPIID, string(api), rpc.EmptyParams, &id); err != nil {
+		return "", errors.Wrapf(err, "failed to get %s api id", api)
+	}
+	c.apiIDs[api] = rpc.APIID(strconv.FormatUint(id, 10))
+	return c.apiIDs[api], nil
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
@@ -13,6 +14,15 @@ import (
 	"time"
 )
 
+// StatusError is returned when the node answers with a status other than 200 OK
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
 type Transport struct {
 	Url    string
 	client http.Client
@@ -22,8 +32,12 @@ type Transport struct {
 }
 
 func NewTransport(url string) *Transport {
-	timeout := time.Duration(20 * time.Second)
+	return NewTransportWithTimeout(url, 20*time.Second)
+}
 
+// NewTransportWithTimeout creates a transport whose requests time out after timeout,
+// 0 means no timeout and leaves deadlines to the context of each call
+func NewTransportWithTimeout(url string, timeout time.Duration) *Transport {
 	return &Transport{
 		client: http.Client{
 			Timeout: timeout,
@@ -37,40 +51,20 @@ func (p *Transport) Connect() error {
 }
 
 func (caller *Transport) Call(api rpc.APIID, method string, args []interface{}, reply interface{}) error {
-	caller.reqMutex.Lock()
-	defer caller.reqMutex.Unlock()
-
-	// increase request id
-	if caller.requestID == math.MaxUint64 {
-		caller.requestID = 0
-	}
-	caller.requestID++
+	return caller.CallContext(context.Background(), api, method, args, reply)
+}
 
+func (caller *Transport) CallContext(ctx context.Context, api rpc.APIID, method string, args []interface{}, reply interface{}) error {
 	request := rpc.RPCRequest{
 		Method: "call",
-		ID:     caller.requestID,
+		ID:     caller.nextID(),
 		Params: []interface{}{api, method, args},
 	}
 
-	reqBody, err := json.Marshal(request)
-	if err != nil {
-		return err
-	}
-
-	resp, err := caller.client.Post(caller.Url, "application/json", bytes.NewBuffer(reqBody))
+	respBody, err := caller.post(ctx, request)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return errors.Wrap(err, "failed to read body")
-	}
 
 	var rpcResponse rpc.RPCResponse
 	if err = json.Unmarshal(respBody, &rpcResponse); err != nil {
@@ -90,6 +84,112 @@ func (caller *Transport) Call(api rpc.APIID, method string, args []interface{},
 	return nil
 }
 
+func (caller *Transport) CallBatch(items []rpc.BatchItem) error {
+	return caller.CallBatchContext(context.Background(), items)
+}
+
+// CallBatchContext sends the items as a JSON array in a single POST and matches the responses by id
+func (caller *Transport) CallBatchContext(ctx context.Context, items []rpc.BatchItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	requests := make([]rpc.RPCRequest, len(items))
+	index := make(map[uint64]int, len(items))
+	for i, item := range items {
+		id := caller.nextID()
+		requests[i] = rpc.RPCRequest{
+			Method: "call",
+			ID:     id,
+			Params: []interface{}{item.API, item.Method, item.Args},
+		}
+		index[id] = i
+	}
+
+	respBody, err := caller.post(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	var rpcResponses []rpc.RPCResponse
+	if err = json.Unmarshal(respBody, &rpcResponses); err != nil {
+		// nodes without batch support answer with a single error object
+		var rpcResponse rpc.RPCResponse
+		if json.Unmarshal(respBody, &rpcResponse) == nil && rpcResponse.Error != nil {
+			return rpcResponse.Error
+		}
+		return errors.Wrapf(err, "failed to unmarshal batch response: %+v", string(respBody))
+	}
+
+	answered := make([]bool, len(items))
+	for _, rpcResponse := range rpcResponses {
+		i, ok := index[rpcResponse.ID]
+		if !ok {
+			continue
+		}
+		answered[i] = true
+
+		if rpcResponse.Error != nil {
+			items[i].Error = rpcResponse.Error
+			continue
+		}
+		if rpcResponse.Result != nil && items[i].Reply != nil {
+			if err := json.Unmarshal(*rpcResponse.Result, items[i].Reply); err != nil {
+				items[i].Error = errors.Wrapf(err, "failed to unmarshal rpc result: %+v", string(*rpcResponse.Result))
+			}
+		}
+	}
+
+	for i := range items {
+		if !answered[i] {
+			items[i].Error = errors.Errorf("no response to %s in batch", items[i].Method)
+		}
+	}
+	return nil
+}
+
+// post sends body as JSON and returns the response body
+func (caller *Transport) post(ctx context.Context, body interface{}) ([]byte, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, caller.Url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := caller.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read body")
+	}
+	return respBody, nil
+}
+
+func (caller *Transport) nextID() uint64 {
+	caller.reqMutex.Lock()
+	defer caller.reqMutex.Unlock()
+
+	// increase request id
+	if caller.requestID == math.MaxUint64 {
+		caller.requestID = 0
+	}
+	caller.requestID++
+	return caller.requestID
+}
+
 func (caller *Transport) SetCallback(api rpc.APIID, method string, notice func(args json.RawMessage)) error {
 	return nil
 }
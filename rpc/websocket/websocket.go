@@ -1,6 +1,8 @@
 package websocket
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"gxclient-go/rpc"
@@ -8,24 +10,87 @@ import (
 	"math"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/websocket"
 )
 
+// login_api always has the id 1, other api ids are handed out by it per connection
+const loginAPIID rpc.APIID = "1"
+
+// ErrNotConnected is returned by calls made while there is no connection to the node
+var ErrNotConnected = errors.New("websocket: not connected")
+
+type ConnectionState int
+
+const (
+	StateConnected ConnectionState = iota
+	StateDisconnected
+	StateReconnecting
+	StateClosed
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+type Options struct {
+	// Reconnect enables reconnecting after the connection is lost
+	Reconnect bool
+	// MaxRetries is the number of reconnect attempts before giving up, 0 means retry forever
+	MaxRetries int
+	// InitialBackoff is the delay before the first reconnect attempt, it doubles on every failure
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between reconnect attempts
+	MaxBackoff time.Duration
+	// OnStateChange is called whenever the connection state changes
+	OnStateChange func(state ConnectionState, err error)
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Reconnect:      true,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
 type Transport struct {
+	url     string
+	options Options
+
 	conn *websocket.Conn
 
-	reqMutex  sync.Mutex
+	// requests are pipelined, any number of calls can wait for their
+	// response while sendMutex only serializes writing to the connection
+	sendMutex sync.Mutex
 	requestID uint64
 	pending   map[uint64]*callRequest
 
 	callbackMutex sync.Mutex
 	callbackID    uint64
 	callbacks     map[uint64]func(args json.RawMessage)
+	subscriptions map[uint64]subscription
+
+	// login calls are replayed after a reconnect, apiIDs maps the api ids
+	// handed out on the first connection to the ones of the current connection
+	handshake []*handshakeCall
+	apiIDs    map[rpc.APIID]rpc.APIID
 
-	closing  bool // user has called Close
-	shutdown bool // server has told us to stop
+	ready    chan struct{} // closed while connected
+	closing  bool          // user has called Close
+	shutdown bool          // server has told us to stop
 
 	mutex sync.Mutex
 }
@@ -37,26 +102,103 @@ type callRequest struct {
 	Reply *json.RawMessage // reply message
 }
 
+type subscription struct {
+	api    rpc.APIID
+	method string
+}
+
+type handshakeCall struct {
+	method string
+	args   []interface{}
+	reply  json.RawMessage
+}
+
 func NewTransport(url string) (*Transport, error) {
-	ws, err := websocket.Dial(url, "", "http://localhost")
+	return NewTransportWithOptions(url, DefaultOptions())
+}
+
+func NewTransportWithOptions(url string, options Options) (*Transport, error) {
+	ws, err := dial(url)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &Transport{
-		conn:      ws,
-		pending:   make(map[uint64]*callRequest),
-		callbacks: make(map[uint64]func(args json.RawMessage)),
+		url:           url,
+		options:       options,
+		conn:          ws,
+		pending:       make(map[uint64]*callRequest),
+		callbacks:     make(map[uint64]func(args json.RawMessage)),
+		subscriptions: make(map[uint64]subscription),
+		apiIDs:        make(map[rpc.APIID]rpc.APIID),
+		ready:         make(chan struct{}),
 	}
+	close(client.ready)
 
-	go client.input()
+	go client.input(ws)
 	return client, nil
 }
 
+func dial(url string) (*websocket.Conn, error) {
+	return websocket.Dial(url, "", "http://localhost")
+}
+
 func (caller *Transport) Call(api rpc.APIID, method string, args []interface{}, reply interface{}) error {
-	caller.reqMutex.Lock()
-	defer caller.reqMutex.Unlock()
+	return caller.CallContext(context.Background(), api, method, args, reply)
+}
+
+// CallContext waits while a reconnect is in progress, unless ctx can never be done:
+// such calls, and those made with Call, fail fast with ErrNotConnected rather than wait without a bound
+func (caller *Transport) CallContext(ctx context.Context, api rpc.APIID, method string, args []interface{}, reply interface{}) error {
+	for {
+		caller.mutex.Lock()
+		if caller.closing || caller.shutdown {
+			caller.mutex.Unlock()
+			return rpc.ErrShutdown
+		}
+		ready := caller.ready
+		caller.mutex.Unlock()
+
+		select {
+		case <-ready:
+		default:
+			if ctx.Done() == nil {
+				return ErrNotConnected
+			}
+			select {
+			case <-ready:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		caller.mutex.Lock()
+		connected := caller.ready == ready
+		caller.mutex.Unlock()
+		if connected {
+			break
+		}
+	}
 
+	var raw json.RawMessage
+	if err := caller.call(ctx, caller.mapAPIID(api), method, args, &raw); err != nil {
+		return err
+	}
+
+	if api == loginAPIID {
+		caller.recordHandshake(method, args, raw)
+	}
+
+	if raw != nil && reply != nil {
+		if err := json.Unmarshal(raw, reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// call sends a request on the current connection and waits for its response
+func (caller *Transport) call(ctx context.Context, api rpc.APIID, method string, args []interface{}, reply *json.RawMessage) error {
 	caller.mutex.Lock()
 	if caller.closing || caller.shutdown {
 		caller.mutex.Unlock()
@@ -73,6 +215,11 @@ func (caller *Transport) Call(api rpc.APIID, method string, args []interface{},
 	c := &callRequest{
 		Done: make(chan bool, 1),
 	}
+	conn := caller.conn
+	if conn == nil {
+		caller.mutex.Unlock()
+		return ErrNotConnected
+	}
 	caller.pending[seq] = c
 	caller.mutex.Unlock()
 
@@ -80,12 +227,15 @@ func (caller *Transport) Call(api rpc.APIID, method string, args []interface{},
 
 	request := rpc.RPCRequest{
 		Method: "call",
-		ID:     caller.requestID,
+		ID:     seq,
 		Params: []interface{}{apiId, method, args},
 	}
 
 	// send Json Rcp request
-	if err := websocket.JSON.Send(caller.conn, request); err != nil {
+	caller.sendMutex.Lock()
+	err := websocket.JSON.Send(conn, request)
+	caller.sendMutex.Unlock()
+	if err != nil {
 		caller.mutex.Lock()
 		delete(caller.pending, seq)
 		caller.mutex.Unlock()
@@ -93,46 +243,53 @@ func (caller *Transport) Call(api rpc.APIID, method string, args []interface{},
 	}
 
 	// wait for the call to complete
-	<-c.Done
+	select {
+	case <-c.Done:
+	case <-ctx.Done():
+		caller.mutex.Lock()
+		delete(caller.pending, seq)
+		caller.mutex.Unlock()
+		return ctx.Err()
+	}
 	if c.Error != nil {
 		return c.Error
 	}
 
-	if c.Reply != nil && reply != nil {
-		if err := json.Unmarshal(*c.Reply, reply); err != nil {
-			return err
-		}
+	if c.Reply != nil {
+		*reply = *c.Reply
 	}
 	return nil
 }
 
-func (caller *Transport) input() {
+func (caller *Transport) input(conn *websocket.Conn) {
 	for {
 		var message string
-		if err := websocket.Message.Receive(caller.conn, &message); err != nil {
-			caller.stop(err)
+		if err := websocket.Message.Receive(conn, &message); err != nil {
+			caller.disconnected(conn, err)
 			return
 		}
 
 		var response rpc.RPCResponse
 		if err := json.Unmarshal([]byte(message), &response); err != nil {
-			caller.stop(err)
+			caller.disconnected(conn, err)
 			return
 		} else {
-			if call, ok := caller.pending[response.ID]; ok {
+			if call := caller.takePending(response.ID); call != nil {
 				caller.onCallResponse(response, call)
 			} else {
 				//the message is not a pending call, but probably a callback notice
 				var incoming rpc.RPCIncoming
 				if err := json.Unmarshal([]byte(message), &incoming); err != nil {
-					caller.stop(err)
+					caller.disconnected(conn, err)
 					return
 				}
 				if incoming.Method == "notice" {
 					if err := caller.onNotice(incoming); err != nil {
-						caller.stop(err)
+						caller.disconnected(conn, err)
 						return
 					}
+				} else if response.ID != 0 {
+					// late response to a call whose context is done
 				} else {
 					log.Printf("protocol error: unknown message received: %+v\n", incoming)
 				}
@@ -141,27 +298,205 @@ func (caller *Transport) input() {
 	}
 }
 
+// disconnected fails the pending calls of a lost connection, then reconnects or shuts down
+func (caller *Transport) disconnected(conn *websocket.Conn, err error) {
+	caller.mutex.Lock()
+	if caller.conn != conn {
+		caller.mutex.Unlock()
+		return
+	}
+	closing := caller.closing
+	if !closing && caller.options.Reconnect {
+		// keep the channel callers already wait on when a reconnect attempt failed
+		select {
+		case <-caller.ready:
+			caller.ready = make(chan struct{})
+		default:
+		}
+	}
+	caller.failPending(err)
+	caller.mutex.Unlock()
+
+	conn.Close()
+
+	if closing {
+		caller.notifyState(StateClosed, nil)
+		return
+	}
+	caller.notifyState(StateDisconnected, err)
+
+	if !caller.options.Reconnect {
+		caller.stop(err)
+		return
+	}
+	caller.reconnect()
+}
+
+func (caller *Transport) reconnect() {
+	backoff := caller.options.InitialBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	for attempt := 1; caller.options.MaxRetries == 0 || attempt <= caller.options.MaxRetries; attempt++ {
+		time.Sleep(backoff)
+		if backoff *= 2; caller.options.MaxBackoff > 0 && backoff > caller.options.MaxBackoff {
+			backoff = caller.options.MaxBackoff
+		}
+
+		caller.mutex.Lock()
+		closing := caller.closing
+		caller.mutex.Unlock()
+		if closing {
+			caller.stop(rpc.ErrShutdown)
+			return
+		}
+
+		caller.notifyState(StateReconnecting, nil)
+		ws, err := dial(caller.url)
+		if err != nil {
+			log.Printf("websocket: reconnect attempt %d failed: %v\n", attempt, err)
+			continue
+		}
+
+		caller.mutex.Lock()
+		caller.conn = ws
+		caller.mutex.Unlock()
+		go caller.input(ws)
+
+		if err := caller.restore(); err != nil {
+			log.Printf("websocket: reconnect attempt %d failed to restore session: %v\n", attempt, err)
+			// detach the connection first so that its input goroutine does not start another reconnect,
+			// the failure counts as an attempt of this loop
+			caller.mutex.Lock()
+			caller.conn = nil
+			caller.failPending(err)
+			caller.mutex.Unlock()
+			ws.Close()
+			continue
+		}
+
+		caller.mutex.Lock()
+		close(caller.ready)
+		caller.mutex.Unlock()
+		caller.notifyState(StateConnected, nil)
+		return
+	}
+
+	caller.stop(errors.Wrapf(ErrNotConnected, "gave up reconnecting after %d attempts", caller.options.MaxRetries))
+}
+
+// restore replays the login handshake and registers the callbacks again on a new connection
+func (caller *Transport) restore() error {
+	caller.mutex.Lock()
+	handshake := make([]*handshakeCall, len(caller.handshake))
+	copy(handshake, caller.handshake)
+	caller.mutex.Unlock()
+
+	for _, h := range handshake {
+		var raw json.RawMessage
+		if err := caller.call(context.Background(), loginAPIID, h.method, h.args, &raw); err != nil {
+			return errors.Wrapf(err, "failed to replay %s", h.method)
+		}
+
+		// an api id handed out by login_api, remember where it moved to
+		var oldID, newID uint64
+		if json.Unmarshal(h.reply, &oldID) == nil && json.Unmarshal(raw, &newID) == nil {
+			caller.mutex.Lock()
+			caller.apiIDs[rpc.APIID(strconv.FormatUint(oldID, 10))] = rpc.APIID(strconv.FormatUint(newID, 10))
+			caller.mutex.Unlock()
+		}
+	}
+
+	caller.callbackMutex.Lock()
+	subscriptions := make(map[uint64]subscription, len(caller.subscriptions))
+	for id, s := range caller.subscriptions {
+		subscriptions[id] = s
+	}
+	caller.callbackMutex.Unlock()
+
+	for id, s := range subscriptions {
+		var raw json.RawMessage
+		if err := caller.call(context.Background(), caller.mapAPIID(s.api), s.method, []interface{}{id}, &raw); err != nil {
+			return errors.Wrapf(err, "failed to register callback %s again", s.method)
+		}
+	}
+	return nil
+}
+
+func (caller *Transport) recordHandshake(method string, args []interface{}, reply json.RawMessage) {
+	rawArgs, _ := json.Marshal(args)
+
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	for _, h := range caller.handshake {
+		if h.method != method {
+			continue
+		}
+		if recorded, _ := json.Marshal(h.args); bytes.Equal(recorded, rawArgs) {
+			return
+		}
+	}
+	caller.handshake = append(caller.handshake, &handshakeCall{method: method, args: args, reply: reply})
+}
+
+func (caller *Transport) mapAPIID(api rpc.APIID) rpc.APIID {
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	if id, ok := caller.apiIDs[api]; ok {
+		return id
+	}
+	return api
+}
+
+func (caller *Transport) notifyState(state ConnectionState, err error) {
+	if caller.options.OnStateChange != nil {
+		caller.options.OnStateChange(state, err)
+	}
+}
+
+// failPending returns the pending calls with err, caller.mutex must be held
+func (caller *Transport) failPending(err error) {
+	for id, call := range caller.pending {
+		call.Error = err
+		call.Done <- true
+		delete(caller.pending, id)
+	}
+}
+
 // Return pending clients and shutdown the client
 func (caller *Transport) stop(err error) {
-	caller.reqMutex.Lock()
+	caller.mutex.Lock()
 	caller.shutdown = true
-	for _, call := range caller.pending {
-		call.Error = err
-		call.Done <- true
+	caller.failPending(err)
+	select {
+	case <-caller.ready:
+	default:
+		close(caller.ready)
 	}
-	caller.reqMutex.Unlock()
+	caller.mutex.Unlock()
+	caller.notifyState(StateClosed, err)
+}
+
+// takePending removes and returns the pending call with the given id, nil if there is none
+func (caller *Transport) takePending(id uint64) *callRequest {
+	caller.mutex.Lock()
+	defer caller.mutex.Unlock()
+	call, ok := caller.pending[id]
+	if !ok {
+		return nil
+	}
+	delete(caller.pending, id)
+	return call
 }
 
 // Call response handler
 func (caller *Transport) onCallResponse(response rpc.RPCResponse, call *callRequest) {
-	caller.mutex.Lock()
-	delete(caller.pending, response.ID)
 	if response.Error != nil {
 		call.Error = response.Error
 	}
 	call.Reply = response.Result
 	call.Done <- true
-	caller.mutex.Unlock()
 }
 
 // Incoming notice handler
@@ -182,8 +517,15 @@ func (caller *Transport) onNotice(incoming rpc.RPCIncoming) error {
 			return errors.Wrapf(err, "failed to parse %s as callbackID in notice %+v", incoming.Params[i], incoming)
 		}
 
+		caller.callbackMutex.Lock()
 		notice := caller.callbacks[callbackID]
+		handedOut := callbackID <= caller.callbackID
+		caller.callbackMutex.Unlock()
 		if notice == nil {
+			if handedOut {
+				// an unset callback the node has not forgotten
+				continue
+			}
 			return fmt.Errorf("callback %d is not registered", callbackID)
 		}
 
@@ -195,16 +537,41 @@ func (caller *Transport) onNotice(incoming rpc.RPCIncoming) error {
 }
 
 func (caller *Transport) SetCallback(api rpc.APIID, method string, notice func(args json.RawMessage)) error {
+	_, err := caller.SetCallbackID(api, method, notice)
+	return err
+}
+
+// SetCallbackID is like SetCallback and returns the id of the callback for UnsetCallback
+func (caller *Transport) SetCallbackID(api rpc.APIID, method string, notice func(args json.RawMessage)) (uint64, error) {
 	// increase callback id
 	caller.callbackMutex.Lock()
 	if caller.callbackID == math.MaxUint64 {
 		caller.callbackID = 0
 	}
 	caller.callbackID++
-	caller.callbacks[caller.callbackID] = notice
+	id := caller.callbackID
+	caller.callbacks[id] = notice
+	caller.subscriptions[id] = subscription{api: api, method: method}
 	caller.callbackMutex.Unlock()
 
-	return caller.Call(api, method, []interface{}{caller.callbackID}, nil)
+	if err := caller.Call(api, method, []interface{}{id}, nil); err != nil {
+		// not registered on the node, it must not be replayed on reconnect either
+		caller.callbackMutex.Lock()
+		delete(caller.callbacks, id)
+		delete(caller.subscriptions, id)
+		caller.callbackMutex.Unlock()
+		return 0, err
+	}
+	return id, nil
+}
+
+// UnsetCallback forgets the callback id, its notices still sent by the node are ignored
+// and it is not registered again on reconnect
+func (caller *Transport) UnsetCallback(id uint64) {
+	caller.callbackMutex.Lock()
+	delete(caller.callbacks, id)
+	delete(caller.subscriptions, id)
+	caller.callbackMutex.Unlock()
 }
 
 func (caller *Transport) Connect() error {
@@ -220,6 +587,11 @@ func (caller *Transport) Close() error {
 		return rpc.ErrShutdown
 	}
 	caller.closing = true
+	conn := caller.conn
 	caller.mutex.Unlock()
-	return caller.conn.Close()
+	if conn == nil {
+		// between reconnect attempts, the reconnect loop stops on closing
+		return nil
+	}
+	return conn.Close()
 }
@@ -0,0 +1,155 @@
+package sign
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The armored layout of a signed message, the one of the signed messages of the Graphene wallets
+// with a nonce in the meta:
+//
+//	-----BEGIN GXCHAIN SIGNED MESSAGE-----
+//	<message>
+//	-----BEGIN META-----
+//	account=<account name>
+//	memokey=<public key of the signature>
+//	timestamp=<RFC 3339 time>
+//	nonce=<random number>
+//	-----BEGIN SIGNATURE-----
+//	<hex of the 65 bytes compact signature>
+//	-----END GXCHAIN SIGNED MESSAGE-----
+//
+// The signature is of the sha256 of the message and the meta, from <message> to the last meta line.
+const (
+	messageBegin   = "-----BEGIN GXCHAIN SIGNED MESSAGE-----"
+	messageEnd     = "-----END GXCHAIN SIGNED MESSAGE-----"
+	metaBegin      = "-----BEGIN META-----"
+	signatureBegin = "-----BEGIN SIGNATURE-----"
+)
+
+// SignedMessage is a message signed by the key of an account, Timestamp and Nonce let the verifier
+// reject old or replayed messages
+type SignedMessage struct {
+	Message   string
+	Account   string
+	PublicKey string
+	Timestamp time.Time
+	Nonce     uint64
+	Signature []byte
+
+	// meta is the meta as read by ParseSignedMessage, kept as is since the signature covers it
+	meta string
+}
+
+func (m *SignedMessage) metaContent() string {
+	if m.meta != "" {
+		return m.meta
+	}
+	return fmt.Sprintf("account=%s\nmemokey=%s\ntimestamp=%s\nnonce=%d",
+		m.Account, m.PublicKey, m.Timestamp.UTC().Format(time.RFC3339), m.Nonce)
+}
+
+// Content is the signed text
+func (m *SignedMessage) Content() string {
+	return m.Message + "\n" + metaBegin + "\n" + m.metaContent()
+}
+
+// String returns the armored message
+func (m *SignedMessage) String() string {
+	return messageBegin + "\n" + m.Content() + "\n" + signatureBegin + "\n" +
+		hex.EncodeToString(m.Signature) + "\n" + messageEnd
+}
+
+// SignMessage signs the content of m with privateKey and sets its signature
+func SignMessage(m *SignedMessage, privateKey *ecdsa.PrivateKey) error {
+	digest := sha256.Sum256([]byte(m.Content()))
+	sig := SignBufferSha256(digest[:], privateKey)
+	if sig == nil {
+		return errors.New("failed to sign message")
+	}
+	m.Signature = sig
+	return nil
+}
+
+// VerifyMessage checks that sig is the signature of msg by pubKey
+func VerifyMessage(pubKey *ecdsa.PublicKey, msg string, sig []byte) error {
+	digest := sha256.Sum256([]byte(msg))
+	recovered, err := RecoverPublicKey(digest[:], sig)
+	if err != nil {
+		return err
+	}
+	if recovered.X.Cmp(pubKey.X) != 0 || recovered.Y.Cmp(pubKey.Y) != 0 {
+		return errors.New("message is not signed by the public key")
+	}
+	return nil
+}
+
+// ParseSignedMessage reads an armored message, the signature is not verified.
+// Meta lines other than account, memokey, timestamp and nonce are ignored but still covered by the signature,
+// a timestamp that is not in RFC 3339 is left zero.
+func ParseSignedMessage(armored string) (*SignedMessage, error) {
+	text := strings.TrimSpace(strings.Replace(armored, "\r\n", "\n", -1))
+
+	if !strings.HasPrefix(text, "-----BEGIN ") {
+		return nil, errors.New("invalid signed message: missing armor")
+	}
+	begin := strings.Index(text, "\n")
+	end := strings.LastIndex(text, "\n")
+	if begin < 0 || end <= begin {
+		return nil, errors.New("invalid signed message: missing content")
+	}
+	if text[end+1:] != messageEnd {
+		return nil, errors.New("invalid signed message: missing armor")
+	}
+	body := text[begin+1 : end]
+
+	metaAt := strings.LastIndex(body, "\n"+metaBegin+"\n")
+	sigAt := strings.LastIndex(body, "\n"+signatureBegin+"\n")
+	if metaAt < 0 || sigAt < 0 {
+		return nil, errors.New("invalid signed message: missing meta or signature")
+	}
+	// the meta starts after the line of metaBegin and must not be empty
+	metaStart := metaAt + len(metaBegin) + 2
+	if sigAt <= metaStart {
+		return nil, errors.New("invalid signed message: missing meta")
+	}
+
+	m := &SignedMessage{
+		Message: body[:metaAt],
+		meta:    body[metaStart:sigAt],
+	}
+
+	sig, err := hex.DecodeString(strings.TrimSpace(body[sigAt+len(signatureBegin)+2:]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed message signature: %v", err)
+	}
+	m.Signature = sig
+
+	for _, line := range strings.Split(m.meta, "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "account":
+			m.Account = kv[1]
+		case "memokey":
+			m.PublicKey = kv[1]
+		case "timestamp":
+			if t, err := time.Parse(time.RFC3339, kv[1]); err == nil {
+				m.Timestamp = t
+			}
+		case "nonce":
+			if nonce, err := strconv.ParseUint(kv[1], 10, 64); err == nil {
+				m.Nonce = nonce
+			}
+		}
+	}
+	return m, nil
+}
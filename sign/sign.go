@@ -78,6 +78,34 @@ func SignBufferSha256(bufSha256 []byte, privateKey *ecdsa.PrivateKey) []byte {
 	}
 }
 
+// RecoverPublicKey returns the public key that produced the 65 bytes compact signature sig of digest
+func RecoverPublicKey(digest, sig []byte) (*secp256k1.PublicKey, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+
+	recID := int(sig[0]) - 27
+	if recID >= 4 {
+		// compressed key flag
+		recID -= 4
+	}
+	if recID < 0 || recID >= 4 {
+		return nil, fmt.Errorf("invalid signature recovery id %d", sig[0])
+	}
+
+	curve := secp256k1.S256()
+	signature := &secp256k1.Signature{
+		R: new(big.Int).SetBytes(sig[1:33]),
+		S: new(big.Int).SetBytes(sig[33:65]),
+	}
+	if signature.R.Sign() == 0 || signature.S.Sign() == 0 ||
+		signature.R.Cmp(curve.Params().N) >= 0 || signature.S.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("invalid signature")
+	}
+
+	return recoverKeyFromSignature(curve, signature, digest, recID, true)
+}
+
 func recoverKeyFromSignature(curve *secp256k1.KoblitzCurve, sig *secp256k1.Signature, msg []byte, iter int, doChecks bool) (*secp256k1.PublicKey, error) {
 	// 1.1 x = (n * i) + r
 	Rx := new(big.Int).Mul(curve.Params().N,
@@ -0,0 +1,67 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"gxclient-go/types"
+	"net/http"
+)
+
+// maxRequestSize bounds the body of a sign request, a request is a public key and a digest
+const maxRequestSize = 1 << 12
+
+type handler struct {
+	signers map[string]types.Signer
+}
+
+// NewHandler returns the http handler of a signing daemon signing with signers, requests name the signer
+// by its public key. Callers are not authenticated, the handler is to be served on a unix socket or
+// behind an authenticating proxy.
+func NewHandler(signers ...types.Signer) http.Handler {
+	h := &handler{signers: map[string]types.Signer{}}
+	for _, s := range signers {
+		h.signers[s.PublicKey().String()] = s
+	}
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/sign" {
+		writeResponse(w, http.StatusNotFound, signResponse{Error: "not found"})
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, signResponse{Error: "method not allowed"})
+		return
+	}
+
+	var req signRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		writeResponse(w, http.StatusBadRequest, signResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+	digest, err := hex.DecodeString(req.Digest)
+	if err != nil || len(digest) != 32 {
+		writeResponse(w, http.StatusBadRequest, signResponse{Error: "digest must be the hex of 32 bytes"})
+		return
+	}
+	s, ok := h.signers[req.PublicKey]
+	if !ok {
+		writeResponse(w, http.StatusNotFound, signResponse{Error: fmt.Sprintf("unknown key %s", req.PublicKey)})
+		return
+	}
+
+	sig, _, err := s.Sign(digest)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, signResponse{Error: err.Error()})
+		return
+	}
+	writeResponse(w, http.StatusOK, signResponse{Signature: hex.EncodeToString(sig)})
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp signResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,145 @@
+// Package signer signs digests in a signing daemon so that private keys do not live in the application process.
+//
+// The daemon answers POST <endpoint>/sign with the json body
+//
+//	{"public_key": "GXC...", "digest": "<hex of the 32 bytes sha256 digest>"}
+//
+// by {"signature": "<hex of the 65 bytes compact signature>"}, or by a status other than 200
+// with {"error": "<message>"}. NewHandler serves this protocol for a set of signers.
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gxclient-go/sign"
+	"gxclient-go/types"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const unixScheme = "unix://"
+
+type signRequest struct {
+	PublicKey string `json:"public_key"`
+	Digest    string `json:"digest"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RemoteSigner is a types.Signer asking a signing daemon to sign with the key of its public key
+type RemoteSigner struct {
+	url       string
+	publicKey *types.PublicKey
+	client    http.Client
+}
+
+// NewRemoteSigner returns a signer for the key of publicKey held by the daemon at endpoint,
+// either http(s)://host:port or unix:///path/of/the/socket
+func NewRemoteSigner(endpoint, publicKey string) (*RemoteSigner, error) {
+	return NewRemoteSignerWithTimeout(endpoint, publicKey, 20*time.Second)
+}
+
+// NewRemoteSignerWithTimeout returns a signer whose requests time out after timeout,
+// 0 means no timeout and leaves deadlines to the context of SignContext
+func NewRemoteSignerWithTimeout(endpoint, publicKey string, timeout time.Duration) (*RemoteSigner, error) {
+	pub, err := types.NewPublicKeyFromString(publicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key: %v", publicKey)
+	}
+
+	s := &RemoteSigner{
+		publicKey: pub,
+		client:    http.Client{Timeout: timeout},
+	}
+
+	switch {
+	case strings.HasPrefix(endpoint, unixScheme):
+		path := strings.TrimPrefix(endpoint, unixScheme)
+		if path == "" {
+			return nil, errors.Errorf("invalid signer endpoint: %v", endpoint)
+		}
+		s.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", path)
+			},
+		}
+		// the host is ignored when dialing the socket
+		s.url = "http://unix/sign"
+	case strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://"):
+		s.url = strings.TrimSuffix(endpoint, "/") + "/sign"
+	default:
+		return nil, errors.Errorf("invalid signer endpoint: %v", endpoint)
+	}
+	return s, nil
+}
+
+func (s *RemoteSigner) PublicKey() *types.PublicKey {
+	return s.publicKey
+}
+
+func (s *RemoteSigner) Sign(digest []byte) ([]byte, *types.PublicKey, error) {
+	return s.SignContext(context.Background(), digest)
+}
+
+// SignContext asks the daemon to sign digest, the signature is checked to recover to the public key of the signer
+func (s *RemoteSigner) SignContext(ctx context.Context, digest []byte) ([]byte, *types.PublicKey, error) {
+	reqBody, err := json.Marshal(signRequest{PublicKey: s.publicKey.String(), Digest: hex.EncodeToString(digest)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to reach signer")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read body")
+	}
+
+	var signResp signResponse
+	if err := json.Unmarshal(respBody, &signResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal response: %+v", string(respBody))
+	}
+	if resp.StatusCode != http.StatusOK || signResp.Error != "" {
+		return nil, nil, errors.Errorf("signer error: %d %s", resp.StatusCode, signResp.Error)
+	}
+
+	sig, err := hex.DecodeString(signResp.Signature)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to decode signature: %v", signResp.Signature)
+	}
+	recovered, err := sign.RecoverPublicKey(digest, sig)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to recover public key of signature: %v", signResp.Signature)
+	}
+	key, err := types.NewPublicKey(recovered)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !key.Equal(s.publicKey) {
+		return nil, nil, errors.Errorf("signer signed with %s instead of %s", key.String(), s.publicKey.String())
+	}
+	return sig, s.publicKey, nil
+}
@@ -0,0 +1,218 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/keypair"
+	"gxclient-go/transaction"
+	"gxclient-go/types"
+	"sync"
+	"testing"
+)
+
+func TestClient_RegisterAccount(t *testing.T) {
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newSigningNode(&broadcasts, &mutex)
+	defer node.close()
+	node.handle("get_config", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"GRAPHENE_MIN_ACCOUNT_NAME_LENGTH": 1, "GRAPHENE_MAX_ACCOUNT_NAME_LENGTH": 63}, nil
+	})
+	node.handle("get_account_by_name", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var name string
+		json.Unmarshal(args[0], &name)
+		switch name {
+		case "test":
+			return map[string]interface{}{"id": "1.2.5", "name": "test"}, nil
+		case "referrer":
+			return map[string]interface{}{"id": "1.2.9", "name": "referrer"}, nil
+		}
+		return nil, nil
+	})
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	keys, err := keypair.DeriveAccountKeys("brain key of alice")
+	require.Nil(t, err)
+	owner, active := keys.Owner.PublicKey().String(), keys.Active.PublicKey().String()
+
+	result, err := client.RegisterAccount("alice-1.gxc", owner, active, "", "referrer", "GXC", true)
+	require.Nil(t, err)
+	require.Len(t, broadcasts, 1)
+
+	op := result.SignedTransaction.Operations[0].(*types.AccountCreateOperation)
+	require.Equal(t, "alice-1.gxc", op.Name)
+	require.Equal(t, uint64(100), op.Fee.Amount)
+	require.Equal(t, "1.2.5", op.Registrar.String())
+	require.Equal(t, "1.2.9", op.Referrer.String())
+	require.Equal(t, active, op.Options.MemoKey.String())
+	for key, weight := range op.Owner.KeyAuths {
+		require.Equal(t, owner, key.String())
+		require.Equal(t, types.UInt16(1), weight)
+	}
+	for key := range op.Active.KeyAuths {
+		require.Equal(t, active, key.String())
+	}
+
+	// the serialized transaction decodes to the same operation
+	raw, err := result.SignedTransaction.Serialize()
+	require.Nil(t, err)
+	decoded, err := types.NewTransactionFromHex(hex.EncodeToString(raw))
+	require.Nil(t, err)
+	require.Equal(t, "alice-1.gxc", decoded.Operations[0].(*types.AccountCreateOperation).Name)
+
+	for _, name := range []string{"", "Alice", "1alice", "alice-", "alice..gxc", "alice_1", "a234567890123456789012345678901234567890123456789012345678901234"} {
+		_, err := client.RegisterAccount(name, owner, active, "", "", "GXC", false)
+		require.NotNil(t, err, name)
+	}
+	_, err = client.RegisterAccount("test", owner, active, "", "", "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.RegisterAccount("bob", owner, "GXC1", "", "", "GXC", false)
+	require.NotNil(t, err)
+
+	result, err = client.RegisterAccount("bob", "", active, "", "", "GXC", false)
+	require.Nil(t, err)
+	op = result.SignedTransaction.Operations[0].(*types.AccountCreateOperation)
+	require.Equal(t, "1.2.5", op.Referrer.String())
+	for key := range op.Owner.KeyAuths {
+		require.Equal(t, active, key.String())
+	}
+}
+
+func TestClient_UpdateAccountAuthority(t *testing.T) {
+	keys := make([]string, 3)
+	for i := range keys {
+		pair, err := keypair.GenerateKeyPair("")
+		require.Nil(t, err)
+		keys[i] = pair.PrivateKey.PublicKey().String()
+	}
+
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newSigningNode(&broadcasts, &mutex)
+	defer node.close()
+	auth := func(threshold int, keyAuths [][]interface{}, accountAuths [][]interface{}) map[string]interface{} {
+		return map[string]interface{}{"weight_threshold": threshold, "key_auths": keyAuths, "account_auths": accountAuths, "address_auths": []interface{}{}}
+	}
+	node.handle("get_accounts", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var ids []string
+		json.Unmarshal(args[0], &ids)
+		accounts := []interface{}{}
+		for _, id := range ids {
+			switch id {
+			case "1.2.5":
+				accounts = append(accounts, map[string]interface{}{
+					"id":      "1.2.5",
+					"name":    "test",
+					"owner":   auth(2, [][]interface{}{{keys[0], 1}, {keys[1], 1}}, [][]interface{}{}),
+					"active":  auth(1, [][]interface{}{{keys[0], 1}}, [][]interface{}{{"1.2.30", 1}}),
+					"options": map[string]interface{}{"memo_key": keys[0], "voting_account": "1.2.5", "num_witness": 0, "num_committee": 0, "votes": []interface{}{}, "extensions": []interface{}{}},
+				})
+			case "1.2.30", "1.2.7":
+				accounts = append(accounts, map[string]interface{}{"id": id})
+			default:
+				accounts = append(accounts, nil)
+			}
+		}
+		return accounts, nil
+	})
+	node.handle("get_account_by_name", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var name string
+		json.Unmarshal(args[0], &name)
+		switch name {
+		case "test":
+			return map[string]interface{}{"id": "1.2.5", "name": "test"}, nil
+		case "bob":
+			return map[string]interface{}{"id": "1.2.7", "name": "bob"}, nil
+		case "ghost":
+			return map[string]interface{}{"id": "1.2.99", "name": "ghost"}, nil
+		}
+		return nil, nil
+	})
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	weights := func(authority *types.Authority) map[string]types.UInt16 {
+		m := map[string]types.UInt16{}
+		for k, v := range authority.KeyAuths {
+			m[k.String()] = v
+		}
+		for k, v := range authority.AccountAuths {
+			m[k.String()] = v
+		}
+		return m
+	}
+	update := func(result *types.TransactionResult) *types.AccountUpdateOperation {
+		return result.SignedTransaction.Operations[0].(*types.AccountUpdateOperation)
+	}
+
+	result, err := client.AddAuthorityKey(gxc.OwnerRole, keys[2], 2, "GXC", false)
+	require.Nil(t, err)
+	require.Nil(t, update(result).Active)
+	require.Equal(t, map[string]types.UInt16{keys[0]: 1, keys[1]: 1, keys[2]: 2}, weights(update(result).Owner))
+
+	result, err = client.AddAuthorityAccount(gxc.ActiveRole, "bob", 3, "GXC", false)
+	require.Nil(t, err)
+	require.Nil(t, update(result).Owner)
+	require.Equal(t, map[string]types.UInt16{keys[0]: 1, "1.2.30": 1, "1.2.7": 3}, weights(update(result).Active))
+
+	// account auths are serialized sorted by instance
+	var b bytes.Buffer
+	require.Nil(t, transaction.NewEncoder(&b).Encode(update(result).Active.AccountAuths))
+	require.Equal(t, "020703001e0100", hex.EncodeToString(b.Bytes()))
+
+	// the serialized transaction decodes back
+	raw, err := result.SignedTransaction.Serialize()
+	require.Nil(t, err)
+	decoded, err := types.NewTransactionFromHex(hex.EncodeToString(raw))
+	require.Nil(t, err)
+	redone, err := types.NewSignedTransaction(decoded).Serialize()
+	require.Nil(t, err)
+	require.Equal(t, raw, redone)
+
+	_, err = client.RemoveAuthorityAccount(gxc.ActiveRole, "bob", "GXC", false)
+	require.NotNil(t, err)
+	result, err = client.RemoveAuthorityKey(gxc.ActiveRole, keys[0], "GXC", false)
+	require.Nil(t, err)
+	require.Equal(t, map[string]types.UInt16{"1.2.30": 1}, weights(update(result).Active))
+
+	// removing a key of the owner would lock the account out
+	_, err = client.RemoveAuthorityKey(gxc.OwnerRole, keys[1], "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.RemoveAuthorityKey(gxc.OwnerRole, keys[2], "GXC", false)
+	require.NotNil(t, err)
+
+	// an account that does not exist does not count
+	ghost := types.Authority{WeightThreshold: 1}
+	ghost.SetAccount(*types.NewGrapheneID("1.2.99"), 1)
+	_, err = client.UpdateAccountAuthority(gxc.ActiveRole, &ghost, "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.AddAuthorityAccount(gxc.ActiveRole, "ghost", 1, "GXC", false)
+	require.Nil(t, err)
+
+	zero := types.Authority{WeightThreshold: 1}
+	_, err = client.UpdateAccountAuthority(gxc.OwnerRole, &zero, "GXC", false)
+	require.NotNil(t, err)
+
+	result, err = client.RotateKeys(keys[1], keys[2], keys[1], "GXC", false)
+	require.Nil(t, err)
+	op := update(result)
+	require.Equal(t, map[string]types.UInt16{keys[1]: 1}, weights(op.Owner))
+	require.Equal(t, map[string]types.UInt16{keys[2]: 1}, weights(op.Active))
+	require.Equal(t, keys[1], op.NewOptions.MemoKey.String())
+	require.Equal(t, "1.2.5", op.NewOptions.VotingAccount.String())
+
+	result, err = client.RotateKeys("", "", keys[2], "GXC", false)
+	require.Nil(t, err)
+	require.Nil(t, update(result).Owner)
+	require.Nil(t, update(result).Active)
+	_, err = client.RotateKeys("", "", "", "GXC", false)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/types"
+	"sync"
+	"testing"
+)
+
+const testMemoKey = "GXC6K35Bajw29N4fjP4XADHtJ7bEj2xHJ8CoY2P2s1igXTB5oMBhR"
+
+// newAssetNode is a signing node knowing GXC, TOKEN issued by the client account 1.2.5 with a supply of
+// 10.00 and OTHER issued by bob 1.2.9
+func newAssetNode(broadcasts *[]json.RawMessage, mutex *sync.Mutex) *wsNode {
+	node := newSigningNode(broadcasts, mutex)
+	node.handle("get_config", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"GRAPHENE_SYMBOL": "GXC", "GRAPHENE_MIN_ASSET_SYMBOL_LENGTH": 3,
+			"GRAPHENE_MAX_ASSET_SYMBOL_LENGTH": 16, "GRAPHENE_MAX_SHARE_SUPPLY": "1000000000000000"}, nil
+	})
+	node.handle("get_account_by_name", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var name string
+		json.Unmarshal(args[0], &name)
+		ids := map[string]string{"test": "1.2.5", "bob": "1.2.9"}
+		if id, ok := ids[name]; ok {
+			return map[string]interface{}{"id": id, "name": name, "options": map[string]interface{}{"memo_key": testMemoKey}}, nil
+		}
+		return nil, nil
+	})
+	assets := map[string]interface{}{
+		"GXC": map[string]interface{}{"id": "1.3.1", "symbol": "GXC", "precision": 5, "issuer": "1.2.3", "dynamic_asset_data_id": "2.3.1"},
+		"TOKEN": map[string]interface{}{"id": "1.3.7", "symbol": "TOKEN", "precision": 2, "issuer": "1.2.5", "dynamic_asset_data_id": "2.3.7",
+			"options": map[string]interface{}{"max_supply": "100000000", "market_fee_percent": 0, "max_market_fee": "1000000000000000",
+				"issuer_permissions": 79, "flags": 2, "core_exchange_rate": map[string]interface{}{
+					"base": map[string]interface{}{"amount": 1, "asset_id": "1.3.7"}, "quote": map[string]interface{}{"amount": 1, "asset_id": "1.3.1"}},
+				"whitelist_authorities": []string{}, "blacklist_authorities": []string{}, "whitelist_markets": []string{}, "blacklist_markets": []string{},
+				"description": "points", "extensions": []interface{}{}}},
+		"OTHER": map[string]interface{}{"id": "1.3.8", "symbol": "OTHER", "precision": 0, "issuer": "1.2.9", "dynamic_asset_data_id": "2.3.8"},
+	}
+	node.handle("lookup_asset_symbols", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var symbols []string
+		json.Unmarshal(args[0], &symbols)
+		var resp []interface{}
+		for _, symbol := range symbols {
+			resp = append(resp, assets[symbol])
+		}
+		return resp, nil
+	})
+	node.handle("get_objects", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var ids []string
+		json.Unmarshal(args[0], &ids)
+		var resp []interface{}
+		for _, id := range ids {
+			resp = append(resp, map[string]interface{}{"id": id, "current_supply": "1000", "confidential_supply": 0, "accumulated_fees": 0, "fee_pool": 50})
+		}
+		return resp, nil
+	})
+	return node
+}
+
+func TestClient_CreateAsset(t *testing.T) {
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newAssetNode(&broadcasts, &mutex)
+	defer node.close()
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	options := types.AssetOptions{IssuerPermissions: uint16(types.AssetFlagWhiteList), Description: "loyalty points"}
+	result, err := client.CreateAsset("LOYAL", 2, "1000000.5", options, "GXC", false)
+	require.Nil(t, err)
+	op := result.SignedTransaction.Operations[0].(*types.AssetCreateOperation)
+	require.Equal(t, uint64(100), op.Fee.Amount)
+	require.Equal(t, "1.2.5", op.Issuer.String())
+	require.Equal(t, "LOYAL", op.Symbol)
+	require.Equal(t, uint8(2), op.Precision)
+	require.Equal(t, int64(100000050), op.CommonOptions.MaxSupply)
+	require.Equal(t, "loyalty points", op.CommonOptions.Description)
+	require.Equal(t, "1.3.0", op.CommonOptions.CoreExchangeRate.Base.AssetID.String())
+	require.Equal(t, "1.3.1", op.CommonOptions.CoreExchangeRate.Quote.AssetID.String())
+	_, err = result.SignedTransaction.Serialize()
+	require.Nil(t, err)
+
+	for _, symbol := range []string{"LO", "1AB", "AB.", "A.B.C", "LOW-ER", "loyal", "ABCDEFGHIJKLMNOPQ", "TOKEN"} {
+		_, err := client.CreateAsset(symbol, 2, "1000", options, "GXC", false)
+		require.NotNil(t, err, symbol)
+	}
+	for _, supply := range []string{"0", "-1", "0.001", "10000000000000000", "1e"} {
+		_, err := client.CreateAsset("LOYAL", 2, supply, options, "GXC", false)
+		require.NotNil(t, err, supply)
+	}
+	_, err = client.CreateAsset("LOYAL", 13, "1", options, "GXC", false)
+	require.NotNil(t, err)
+}
+
+func TestClient_IssueAndManageAsset(t *testing.T) {
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newAssetNode(&broadcasts, &mutex)
+	defer node.close()
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	result, err := client.IssueAsset("bob", "12.5 TOKEN", "welcome", "GXC", false)
+	require.Nil(t, err)
+	issue := result.SignedTransaction.Operations[0].(*types.AssetIssueOperation)
+	require.Equal(t, uint64(1250), issue.AssetToIssue.Amount)
+	require.Equal(t, "1.3.7", issue.AssetToIssue.AssetID.String())
+	require.Equal(t, "1.2.9", issue.IssueToAccount.String())
+	require.NotNil(t, issue.Memo)
+
+	result, err = client.IssueAsset("bob", "1 TOKEN", "", "GXC", false)
+	require.Nil(t, err)
+	require.Nil(t, result.SignedTransaction.Operations[0].(*types.AssetIssueOperation).Memo)
+
+	// the supply is 10.00 of 1000000.00
+	_, err = client.IssueAsset("bob", "999990 TOKEN", "", "GXC", false)
+	require.Nil(t, err)
+	for _, amount := range []string{"999990.01 TOKEN", "1.234 TOKEN", "1 OTHER", "1 NONE", "1TOKEN"} {
+		_, err := client.IssueAsset("bob", amount, "", "GXC", false)
+		require.NotNil(t, err, amount)
+	}
+
+	result, err = client.ReserveAsset("5 TOKEN", "GXC", false)
+	require.Nil(t, err)
+	reserve := result.SignedTransaction.Operations[0].(*types.AssetReserveOperation)
+	require.Equal(t, uint64(500), reserve.AmountToReserve.Amount)
+	require.Equal(t, "1.2.5", reserve.Payer.String())
+
+	asset, err := client.Database.GetAssetDetails("TOKEN")
+	require.Nil(t, err)
+	options := asset.Options
+	options.Flags = 0
+	result, err = client.UpdateAsset("TOKEN", "bob", options, "GXC", false)
+	require.Nil(t, err)
+	update := result.SignedTransaction.Operations[0].(*types.AssetUpdateOperation)
+	require.Equal(t, "1.3.7", update.AssetToUpdate.String())
+	require.Equal(t, "1.2.9", update.NewIssuer.String())
+	require.Equal(t, uint16(0), update.NewOptions.Flags)
+	require.Equal(t, int64(100000000), update.NewOptions.MaxSupply)
+
+	options.MaxSupply = 999
+	_, err = client.UpdateAsset("TOKEN", "", options, "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.UpdateAsset("OTHER", "", options, "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.UpdateAsset("TOKEN", "test", asset.Options, "GXC", false)
+	require.NotNil(t, err)
+
+	// the fee pool only takes the core asset
+	_, err = client.FundFeePool("TOKEN", "100 TOKEN", "GXC", true)
+	require.NotNil(t, err)
+	require.Empty(t, broadcasts)
+
+	result, err = client.FundFeePool("TOKEN", "10 GXC", "GXC", true)
+	require.Nil(t, err)
+	require.Len(t, broadcasts, 1)
+	fund := result.SignedTransaction.Operations[0].(*types.AssetFundFeePoolOperation)
+	require.Equal(t, types.Share(1000000), fund.Amount)
+	require.Equal(t, "1.3.7", fund.AssetID.String())
+
+	raw, err := result.SignedTransaction.Serialize()
+	require.Nil(t, err)
+	decoded, err := types.NewTransactionFromHex(hex.EncodeToString(raw))
+	require.Nil(t, err)
+	require.Equal(t, fund, decoded.Operations[0])
+}
+
+func TestDatabase_GetAssetDetails(t *testing.T) {
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newAssetNode(&broadcasts, &mutex)
+	defer node.close()
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	asset, err := client.Database.GetAssetDetails("TOKEN")
+	require.Nil(t, err)
+	require.Equal(t, int64(100000000), asset.Options.MaxSupply)
+	require.Equal(t, int64(1000000000000000), asset.Options.MaxMarketFee)
+	require.True(t, asset.Options.HasFlag(types.AssetFlagWhiteList))
+	require.False(t, asset.Options.HasFlag(types.AssetFlagChargeMarketFee))
+	require.True(t, asset.Options.HasPermission(types.AssetFlagOverrideAuthority))
+	require.Equal(t, "2.3.7", asset.DynamicData.ID.String())
+	require.Equal(t, types.Share(1000), asset.DynamicData.CurrentSupply)
+	require.Equal(t, types.Share(50), asset.DynamicData.FeePool)
+
+	_, err = client.Database.GetAssetDetails("NONE")
+	require.NotNil(t, err)
+
+	// a node answering without the dynamic data
+	node.handle("get_objects", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return []interface{}{}, nil
+	})
+	_, err = client.Database.GetAssetDetails("TOKEN")
+	require.NotNil(t, err)
+}
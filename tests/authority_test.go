@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/keypair"
+	"gxclient-go/sign"
+	"gxclient-go/types"
+	"sync"
+	"testing"
+)
+
+func TestSign_RecoverPublicKey(t *testing.T) {
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+
+	digest := sha256.Sum256([]byte("message"))
+	sig := sign.SignBufferSha256(digest[:], key.ToECDSA())
+	pub, err := sign.RecoverPublicKey(digest[:], sig)
+	require.Nil(t, err)
+	recovered, err := types.NewPublicKey(pub)
+	require.Nil(t, err)
+	require.Equal(t, key.PublicKey().String(), recovered.String())
+
+	_, err = sign.RecoverPublicKey(digest[:], sig[1:])
+	require.NotNil(t, err)
+}
+
+func TestClient_VerifyAuthority(t *testing.T) {
+	keys := make([]*keypair.KeyPair, 4)
+	for i := range keys {
+		var err error
+		keys[i], err = keypair.GenerateKeyPair("")
+		require.Nil(t, err)
+	}
+	wif := func(i int) string { return keys[i].PrivateKey.ToWIF() }
+	pub := func(i int) string { return keys[i].PrivateKey.PublicKey().String() }
+
+	var mutex sync.Mutex
+	var head, irreversible uint32
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, &mutex, &callbackID)
+	defer node.close()
+	node.handle("get_chain_id", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return testChainID, nil
+	})
+	// 1.2.30 is satisfied by key 1 or by 1.2.31, 1.2.31 by key 2 or by 1.2.32, 1.2.32 by key 3
+	actives := map[string]interface{}{
+		"1.2.30": map[string]interface{}{"weight_threshold": 1, "key_auths": [][]interface{}{{pub(1), 1}}, "account_auths": [][]interface{}{{"1.2.31", 1}}, "address_auths": []interface{}{}},
+		"1.2.31": map[string]interface{}{"weight_threshold": 1, "key_auths": [][]interface{}{{pub(2), 1}}, "account_auths": [][]interface{}{{"1.2.32", 1}}, "address_auths": []interface{}{}},
+		"1.2.32": map[string]interface{}{"weight_threshold": 1, "key_auths": [][]interface{}{{pub(3), 1}}, "account_auths": [][]interface{}{}, "address_auths": []interface{}{}},
+	}
+	node.handle("get_accounts", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var ids []string
+		json.Unmarshal(args[0], &ids)
+		accounts := []interface{}{}
+		for _, id := range ids {
+			accounts = append(accounts, map[string]interface{}{"id": id, "active": actives[id]})
+		}
+		return accounts, nil
+	})
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	var authority types.Authority
+	require.Nil(t, json.Unmarshal([]byte(`{"weight_threshold":3,"key_auths":[["`+pub(0)+`",1]],"account_auths":[["1.2.30",2]],"address_auths":[]}`), &authority))
+
+	verify := func(signers ...int) error {
+		stx := multisigTx()
+		for _, i := range signers {
+			require.Nil(t, stx.AppendSignature(wif(i), testChainID))
+		}
+		return client.VerifyAuthority(stx, &authority)
+	}
+
+	require.Equal(t, gxc.ErrAuthorityNotSatisfied, verify(0))
+	require.Equal(t, gxc.ErrAuthorityNotSatisfied, verify(1))
+	require.Nil(t, verify(0, 1))
+	require.Nil(t, verify(0, 2))
+	// 1.2.32 is nested deeper than the chain checks
+	require.Equal(t, gxc.ErrAuthorityNotSatisfied, verify(0, 3))
+
+	stx := multisigTx()
+	require.Nil(t, stx.AppendSignature(wif(0), testChainID))
+	require.Nil(t, stx.AppendSignature(wif(1), testChainID))
+	signers, err := stx.SignerKeys(testChainID)
+	require.Nil(t, err)
+	require.Equal(t, pub(0), signers[0].String())
+	require.Equal(t, pub(1), signers[1].String())
+}
@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/transaction"
+	"gxclient-go/types"
+	"testing"
+)
+
+func TestContract_CallContractOperationSerialize(t *testing.T) {
+	fee := types.AssetAmount{Amount: 100, AssetID: types.MustParseObjectID("1.3.1")}
+	op := types.NewCallContractOperation(types.MustParseObjectID("1.2.17"), types.MustParseObjectID("1.2.18"), "hi", []byte{0x01, 0x02}, nil, fee)
+
+	var b bytes.Buffer
+	require.Nil(t, transaction.NewEncoder(&b).Encode(op))
+	require.Equal(t, "4b"+"640000000000000001"+"11"+"12"+"00"+"000000000000806b"+"020102"+"00", hex.EncodeToString(b.Bytes()))
+}
+
+var testAbi = types.Abi{
+	Version: "gxc::abi/1.0",
+	Types:   []types.TypeDef{{NewTypeName: "account_name", Type: "name"}},
+	Structs: []types.Struct{
+		{Name: "base", Fields: []types.Field{{Name: "memo", Type: "string"}}},
+		{Name: "issue", Base: "base", Fields: []types.Field{
+			{Name: "to", Type: "account_name"},
+			{Name: "amount", Type: "uint64"},
+			{Name: "tags", Type: "string[]"},
+			{Name: "note", Type: "string?"},
+			{Name: "asset", Type: "contract_asset"},
+		}},
+	},
+	Actions: []types.Action{{Name: "issue", Type: "issue", Payable: false}},
+}
+
+func TestContract_SerializeActionData(t *testing.T) {
+	params := `{"memo":"hi","to":"alice","amount":1000,"tags":["a","b"],"asset":{"amount":-5,"asset_id":1}}`
+	data, err := testAbi.SerializeActionData("issue", params)
+	require.Nil(t, err)
+	require.Equal(t, "026869"+"0000000000855c34"+"e803000000000000"+"0201610162"+"00"+"fbffffffffffffff0100000000000000", hex.EncodeToString(data))
+
+	decoded, err := testAbi.DeserializeActionData("issue", data)
+	require.Nil(t, err)
+	require.Equal(t, "alice", decoded["to"])
+	require.Equal(t, uint64(1000), decoded["amount"])
+	require.Nil(t, decoded["note"])
+
+	// decoded values serialize back to the same bytes
+	again, err := testAbi.SerializeActionData("issue", decoded)
+	require.Nil(t, err)
+	require.Equal(t, data, again)
+
+	_, err = testAbi.SerializeActionData("issue", map[string]interface{}{"memo": "hi"})
+	require.Error(t, err)
+}
+
+func TestContract_DeserializeMalformedActionData(t *testing.T) {
+	abi := types.Abi{
+		Structs: []types.Struct{{Name: "blob", Fields: []types.Field{{Name: "data", Type: "bytes"}, {Name: "ids", Type: "uint64[]"}}}},
+		Actions: []types.Action{{Name: "blob", Type: "blob"}},
+	}
+
+	data, err := hex.DecodeString("020102" + "01" + "0100000000000000")
+	require.Nil(t, err)
+	decoded, err := abi.DeserializeActionData("blob", data)
+	require.Nil(t, err)
+	require.Equal(t, "0102", decoded["data"])
+
+	// lengths and counts larger than the data are errors rather than huge allocations
+	for _, malformed := range []string{
+		"ffffffffffffffff7f",
+		"ffffffff0f00",
+		"0201",
+		"00ffffffffffffffff7f",
+		"00ffffffff0f0100000000000000",
+		"0002010000000000000000",
+	} {
+		data, err := hex.DecodeString(malformed)
+		require.Nil(t, err)
+		_, err = abi.DeserializeActionData("blob", data)
+		require.NotNil(t, err, malformed)
+	}
+}
+
+func TestContract_RecursiveAbi(t *testing.T) {
+	for name, abi := range map[string]types.Abi{
+		"self": {
+			Structs: []types.Struct{{Name: "node", Fields: []types.Field{{Name: "next", Type: "node"}}}},
+			Actions: []types.Action{{Name: "node", Type: "node"}},
+		},
+		"base": {
+			Structs: []types.Struct{
+				{Name: "node", Base: "other", Fields: []types.Field{{Name: "id", Type: "uint64"}}},
+				{Name: "other", Base: "node"},
+			},
+			Actions: []types.Action{{Name: "node", Type: "node"}},
+		},
+	} {
+		// nesting past the limit is an error rather than a stack overflow
+		_, err := abi.DeserializeActionData("node", make([]byte, 8))
+		require.NotNil(t, err, name)
+		require.Contains(t, err.Error(), "nested deeper", name)
+
+		_, err = abi.SerializeActionData("node", map[string]interface{}{"id": 1})
+		require.NotNil(t, err, name)
+	}
+
+	// a self referencing struct still encodes values of a bounded depth
+	list := types.Abi{
+		Structs: []types.Struct{{Name: "node", Fields: []types.Field{{Name: "id", Type: "uint8"}, {Name: "next", Type: "node?"}}}},
+		Actions: []types.Action{{Name: "node", Type: "node"}},
+	}
+	data, err := list.SerializeActionData("node", `{"id":1,"next":{"id":2}}`)
+	require.Nil(t, err)
+	require.Equal(t, "01"+"01"+"02"+"00", hex.EncodeToString(data))
+	decoded, err := list.DeserializeActionData("node", data)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), decoded["next"].(map[string]interface{})["id"])
+}
+
+func TestContract_DeployAndUpdateRoundTrip(t *testing.T) {
+	abi := testAbi
+	abi.Tables = []types.Table{{Name: "account", IndexType: "i64", KeyNames: []string{"owner"}, KeyTypes: []string{"uint64"}, Type: "issue"}}
+	abi.ErrorMessages = []types.ErrorMessage{{ErrorCode: 1, ErrorMsg: "overdrawn"}}
+	newOwner := types.MustParseObjectID("1.2.19")
+
+	for _, op := range []types.Operation{
+		types.NewCreateContractOperation(types.MustParseObjectID("1.2.17"), "bank", "0", "0", types.Buffer{0x00, 0x61, 0x73, 0x6d}, abi, testFee),
+		types.NewUpdateContractOperation(types.MustParseObjectID("1.2.17"), types.MustParseObjectID("1.2.18"), &newOwner, types.Buffer{0x01}, abi, testFee),
+		types.NewUpdateContractOperation(types.MustParseObjectID("1.2.17"), types.MustParseObjectID("1.2.18"), nil, types.Buffer{0x01}, types.Abi{}, testFee),
+	} {
+		tx := &types.Transaction{Expiration: testTime, Operations: types.Operations{op}}
+		var b bytes.Buffer
+		require.Nil(t, transaction.NewEncoder(&b).Encode(tx))
+
+		decoded, err := types.NewTransactionFromHex(hex.EncodeToString(b.Bytes()))
+		require.Nil(t, err)
+		require.Equal(t, op, decoded.Operations[0])
+
+		var again bytes.Buffer
+		require.Nil(t, transaction.NewEncoder(&again).Encode(decoded))
+		require.Equal(t, b.Bytes(), again.Bytes())
+	}
+}
+
+func TestClient_CallContract(t *testing.T) {
+	client, err := gxc.NewClient(testPri, testPri, testAccountName, testNetHttp)
+	require.Nil(t, err)
+
+	result, err := client.CallContract("bank", "withdraw", map[string]interface{}{"to_account": testAccountName, "amount": map[string]interface{}{"asset_id": 1, "amount": 100000}}, "", "GXC", false)
+	require.NoError(t, err)
+	str, _ := json.Marshal(*result)
+	fmt.Println(string(str))
+}
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/transaction"
+	"gxclient-go/types"
+	"strings"
+	"testing"
+)
+
+func TestDecoder_Transaction(t *testing.T) {
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+	pub := key.PublicKey()
+
+	account := types.MustParseObjectID("1.2.17")
+	other := types.MustParseObjectID("1.2.18")
+
+	memo := &types.Memo{From: *pub, To: *pub, Nonce: 7}
+	require.Nil(t, memo.Encrypt(key, "hello"))
+
+	authority := types.Authority{
+		WeightThreshold: 1,
+		AccountAuths:    types.AccountAuthsMap{*types.NewGrapheneID("1.2.5"): 1},
+		KeyAuths:        types.KeyAuthsMap{pub: 1},
+		AddressAuths:    types.AddressAuthsMap{},
+		Extensions:      types.Extensions{},
+	}
+	options := types.AccountOptions{
+		MemoKey:       *pub,
+		VotingAccount: *types.NewGrapheneID("1.2.5"),
+		NumWitness:    1,
+		Votes:         types.Votes{*types.NewVoteIDV2("1:22")},
+		Extensions:    types.Extensions{},
+	}
+	accountCreate := types.NewAccountCreateOperation(*types.NewGrapheneID("1.2.17"), *types.NewGrapheneID("1.2.17"), 0,
+		authority, authority, "alice", options)
+	accountCreate.SetFee(testFee)
+
+	contractAmount := testAmount
+	tx := &types.Transaction{
+		RefBlockNum:    12,
+		RefBlockPrefix: 3456,
+		Expiration:     testTime,
+		Operations: types.Operations{
+			types.NewTransferOperation(account, other, testAmount, testFee, memo),
+			types.NewStakingCreateOperation(account, types.MustParseObjectID("1.6.3"), testAmount, testFee, "1", 10, 7),
+			types.NewCallContractOperation(account, other, "hi", types.Buffer{1, 2}, &contractAmount, testFee),
+			accountCreate,
+			types.NewAccountUpdateOperation(account, nil, &authority, &options, testFee),
+			types.NewProposalCreateOperation(account, []types.Operation{types.NewAssetReserveOperation(account, testAmount, testFee)}, testTime, nil, testFee),
+		},
+	}
+
+	var b bytes.Buffer
+	require.Nil(t, transaction.NewEncoder(&b).Encode(tx))
+	serialized := hex.EncodeToString(b.Bytes())
+
+	// unsigned transaction
+	decoded, err := types.NewTransactionFromHex(serialized)
+	require.Nil(t, err)
+	require.Equal(t, uint16(12), decoded.RefBlockNum)
+	require.Equal(t, uint32(3456), decoded.RefBlockPrefix)
+	require.True(t, tx.Expiration.Equal(*decoded.Expiration.Time))
+	require.Len(t, decoded.Operations, len(tx.Operations))
+	require.Empty(t, decoded.Signatures)
+
+	transfer := decoded.Operations[0].(*types.TransferOperation)
+	require.Equal(t, "1.2.18", transfer.To.String())
+	require.Equal(t, "1.3.1", transfer.Amount.AssetID.String())
+	require.Equal(t, uint64(1000), transfer.Amount.Amount)
+	message, err := transfer.Memo.Decrypt(key)
+	require.Nil(t, err)
+	require.Equal(t, "hello", message)
+
+	staking := decoded.Operations[1].(*types.StakingCreateOperation)
+	require.Equal(t, "1.6.3", staking.TrustNode.String())
+
+	call := decoded.Operations[2].(*types.CallContractOperation)
+	require.Equal(t, "hi", call.MethodName)
+	require.Equal(t, "0102", call.Data.String())
+
+	create := decoded.Operations[3].(*types.AccountCreateOperation)
+	require.Equal(t, "alice", create.Name)
+	require.Equal(t, "1.2.17", create.Registrar.String())
+	require.Equal(t, types.UInt16(1), create.Owner.AccountAuths[*types.NewGrapheneID("1.2.5")])
+
+	proposal := decoded.Operations[5].(*types.ProposalCreateOperation)
+	require.IsType(t, &types.AssetReserveOperation{}, proposal.ProposedOps[0].Op)
+
+	var reencoded bytes.Buffer
+	require.Nil(t, transaction.NewEncoder(&reencoded).Encode(decoded))
+	require.Equal(t, serialized, hex.EncodeToString(reencoded.Bytes()))
+
+	// signed transaction serialization
+	signature := strings.Repeat("1f", 65)
+	decoded, err = types.NewTransactionFromHex(serialized + "01" + signature)
+	require.Nil(t, err)
+	require.Equal(t, []string{signature}, decoded.Signatures)
+
+	_, err = types.NewTransactionFromHex(serialized + "00" + "ff")
+	require.NotNil(t, err)
+	_, err = types.NewTransactionFromHex(serialized[:len(serialized)-4])
+	require.NotNil(t, err)
+}
+
+func TestDecoder_UnsupportedOperation(t *testing.T) {
+	// asset creation with bitasset options
+	_, optionsHex := testAssetOptions()
+	header := "0c00" + "800d0000" + "00000000"
+	_, err := types.NewTransactionFromHex(header + "01" + "0a" + testFeeHex + "11" + "0454455354" + "05" + optionsHex + "01")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "bitasset options are not supported")
+}
+
+func TestDecoder_MalformedLengths(t *testing.T) {
+	header := "0c00" + "800d0000" + "00000000"
+	huge := "ffffffff07"
+
+	// operation count larger than the remaining bytes
+	_, err := types.NewTransactionFromHex(header + huge + "00")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "remaining bytes")
+
+	// signature count larger than the remaining bytes
+	_, err = types.NewTransactionFromHex(header + "00" + "00" + huge + strings.Repeat("1f", 65))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "remaining bytes")
+
+	// buffer length larger than the remaining bytes
+	var buffer types.Buffer
+	err = transaction.NewDecoder(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x07, 0x01})).Decode(&buffer)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "remaining bytes")
+
+	// lengths over 32 bits are rejected before the remaining bytes are looked at
+	var votes types.Votes
+	err = transaction.NewDecoder(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0x01})).Decode(&votes)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "too large")
+}
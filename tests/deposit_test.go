@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/deposit"
+	"gxclient-go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func depositTransfer(t *testing.T, to string, amount uint64, memo string) types.Operation {
+	var memoOb *types.Memo
+	if memo != "" {
+		key, err := types.NewPrivateKeyFromWif(testPri)
+		require.Nil(t, err)
+		memoOb = &types.Memo{From: *key.PublicKey(), To: *key.PublicKey(), Nonce: 1}
+		require.Nil(t, memoOb.Encrypt(key, memo))
+	}
+	return types.NewTransferOperation(types.MustParseObjectID("1.2.6"), types.MustParseObjectID(to),
+		types.AssetAmount{Amount: amount, AssetID: types.MustParseObjectID("1.3.1")},
+		types.AssetAmount{AssetID: types.MustParseObjectID("1.3.1")}, memoOb)
+}
+
+func depositTx(ops ...types.Operation) *types.Transaction {
+	return &types.Transaction{Expiration: types.NewTime(time.Now().UTC()), Operations: ops}
+}
+
+func TestDeposit_Monitor(t *testing.T) {
+	var mutex sync.Mutex
+	head, irreversible := uint32(6), uint32(5)
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, &mutex, &callbackID)
+	defer node.close()
+
+	transactions := map[uint32][]*types.Transaction{
+		3: {
+			depositTx(depositTransfer(t, "1.2.7", 1, "")),
+			depositTx(depositTransfer(t, "1.2.7", 2, ""), depositTransfer(t, "1.2.5", 1000, "order-42")),
+		},
+		4: {depositTx(depositTransfer(t, "1.2.5", 2000, ""))},
+		// reversible, not handled yet
+		6: {depositTx(depositTransfer(t, "1.2.5", 3000, ""))},
+	}
+	node.handle("get_block", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var num uint32
+		json.Unmarshal(args[0], &num)
+		ids := []string{}
+		for i := range transactions[num] {
+			ids = append(ids, fmt.Sprintf("tx-%d-%d", num, i))
+		}
+		txs := transactions[num]
+		if txs == nil {
+			txs = []*types.Transaction{}
+		}
+		return map[string]interface{}{"block_id": fmt.Sprintf("%08x", num), "transactions": txs, "transaction_ids": ids}, nil
+	})
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	store := &deposit.MemoryStore{}
+	require.Nil(t, store.Save(1))
+	monitor, err := deposit.NewMonitor(client, "test", testPri, store)
+	require.Nil(t, err)
+
+	// a failing handler stops the monitor before the checkpoint passes the block
+	failed := errors.New("database down")
+	err = monitor.Run(context.Background(), func(d *deposit.Deposit) error {
+		if d.BlockNum == 4 {
+			return failed
+		}
+		return nil
+	})
+	require.Equal(t, failed, err)
+	checkpoint, ok, err := store.Load()
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint32(3), checkpoint)
+
+	// after a restart the monitor resumes with block 4
+	var deposits []*deposit.Deposit
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- monitor.Run(ctx, func(d *deposit.Deposit) error {
+			deposits = append(deposits, d)
+			return nil
+		})
+	}()
+	require.Eventually(t, func() bool {
+		checkpoint, _, _ := store.Load()
+		return checkpoint == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.Equal(t, context.Canceled, <-done)
+
+	require.Len(t, deposits, 1)
+	require.Equal(t, "4.0.0", deposits[0].ID)
+	require.Equal(t, "tx-4-0", deposits[0].TxID)
+	require.Equal(t, uint64(2000), deposits[0].Amount.Amount)
+	require.Equal(t, "1.2.6", deposits[0].From.String())
+	require.Equal(t, "", deposits[0].Memo)
+
+	// the memo is decrypted with the memo key
+	store = &deposit.MemoryStore{}
+	require.Nil(t, store.Save(2))
+	monitor, err = deposit.NewMonitor(client, "test", testPri, store)
+	require.Nil(t, err)
+	err = monitor.Run(context.Background(), func(d *deposit.Deposit) error {
+		require.Equal(t, "3.1.1", d.ID)
+		require.Equal(t, "tx-3-1", d.TxID)
+		require.Nil(t, d.MemoErr)
+		require.Equal(t, "order-42", d.Memo)
+		return failed
+	})
+	require.Equal(t, failed, err)
+}
+
+func TestDeposit_MalformedMemo(t *testing.T) {
+	var mutex sync.Mutex
+	head, irreversible := uint32(4), uint32(3)
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, &mutex, &callbackID)
+	defer node.close()
+
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+	// a message that is not a whole number of aes blocks
+	truncated := depositTransfer(t, "1.2.5", 1000, "order-42").(*types.TransferOperation)
+	truncated.Memo.Message = truncated.Memo.Message[:5]
+	// a whole block whose padding byte claims more bytes than the block holds
+	forged := depositTransfer(t, "1.2.5", 2000, "").(*types.TransferOperation)
+	forged.Memo = &types.Memo{From: *key.PublicKey(), To: *key.PublicKey(), Nonce: 1}
+	require.Nil(t, forged.Memo.Encrypt(key, "order-43 of the long reference"))
+	forged.Memo.Message = forged.Memo.Message[:16]
+
+	node.handle("get_block", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var num uint32
+		json.Unmarshal(args[0], &num)
+		txs := []*types.Transaction{}
+		if num == 3 {
+			txs = append(txs, depositTx(truncated, forged, depositTransfer(t, "1.2.5", 3000, "order-44")))
+		}
+		return map[string]interface{}{"block_id": fmt.Sprintf("%08x", num), "transactions": txs, "transaction_ids": []string{}}, nil
+	})
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	store := &deposit.MemoryStore{}
+	require.Nil(t, store.Save(2))
+	monitor, err := deposit.NewMonitor(client, "test", testPri, store)
+	require.Nil(t, err)
+
+	// bad memos are reported on their deposit, the following ones are still handled
+	var deposits []*deposit.Deposit
+	stop := errors.New("stop")
+	err = monitor.Run(context.Background(), func(d *deposit.Deposit) error {
+		deposits = append(deposits, d)
+		if len(deposits) == 3 {
+			return stop
+		}
+		return nil
+	})
+	require.Equal(t, stop, err)
+
+	require.Equal(t, types.ErrInvalidMemo, deposits[0].MemoErr)
+	require.Equal(t, "", deposits[0].Memo)
+	require.NotNil(t, deposits[1].MemoErr)
+	require.Nil(t, deposits[2].MemoErr)
+	require.Equal(t, "order-44", deposits[2].Memo)
+}
+
+func TestDeposit_FileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deposit")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := deposit.NewFileStore(filepath.Join(dir, "checkpoint"))
+	_, ok, err := store.Load()
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	require.Nil(t, store.Save(42))
+	require.Nil(t, store.Save(43))
+	blockNum, ok, err := store.Load()
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint32(43), blockNum)
+}
@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/api/database"
+	"gxclient-go/rpc/failover"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// httpNode is a minimal http node answering get_chain_id and get_dynamic_global_properties
+type httpNode struct {
+	server *httptest.Server
+
+	mutex     sync.Mutex
+	chainID   string
+	headBlock uint32
+	delay     time.Duration
+	down      bool
+	calls     int
+}
+
+func newHTTPNode(chainID string, headBlock uint32) *httpNode {
+	node := &httpNode{chainID: chainID, headBlock: headBlock}
+	node.server = httptest.NewServer(nethttp.HandlerFunc(node.serve))
+	return node
+}
+
+func (node *httpNode) set(fn func(node *httpNode)) {
+	node.mutex.Lock()
+	fn(node)
+	node.mutex.Unlock()
+}
+
+func (node *httpNode) serve(w nethttp.ResponseWriter, r *nethttp.Request) {
+	var request struct {
+		ID     uint64            `json:"id"`
+		Params []json.RawMessage `json:"params"`
+	}
+	json.NewDecoder(r.Body).Decode(&request)
+	var method string
+	json.Unmarshal(request.Params[1], &method)
+
+	node.mutex.Lock()
+	node.calls++
+	down, delay := node.down, node.delay
+	response := map[string]interface{}{"id": request.ID}
+	switch method {
+	case "get_chain_id":
+		response["result"] = node.chainID
+	case "get_dynamic_global_properties":
+		response["result"] = map[string]interface{}{"head_block_number": node.headBlock}
+	default:
+		response["error"] = map[string]interface{}{"code": 1, "message": "unknown method " + method}
+	}
+	node.mutex.Unlock()
+
+	time.Sleep(delay)
+	if down {
+		w.WriteHeader(nethttp.StatusBadGateway)
+		return
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func failoverOptions() failover.Options {
+	options := failover.DefaultOptions()
+	options.ProbeInterval = time.Hour
+	return options
+}
+
+func TestFailover_ChainIdMismatch(t *testing.T) {
+	a := newHTTPNode("chain-a", 100)
+	defer a.server.Close()
+	b := newHTTPNode("chain-b", 100)
+	defer b.server.Close()
+
+	_, err := failover.NewTransportWithOptions([]string{a.server.URL, b.server.URL}, failoverOptions())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "chain id mismatch")
+}
+
+func TestFailover_Routing(t *testing.T) {
+	slow := newHTTPNode("chain", 100)
+	defer slow.server.Close()
+	slow.delay = 50 * time.Millisecond
+	fast := newHTTPNode("chain", 101)
+	defer fast.server.Close()
+	// fastest, but too far behind the best head block
+	behind := newHTTPNode("chain", 90)
+	defer behind.server.Close()
+
+	transport, err := failover.NewTransportWithOptions([]string{slow.server.URL, behind.server.URL, fast.server.URL}, failoverOptions())
+	require.Nil(t, err)
+	defer transport.Close()
+	require.Equal(t, "chain", transport.ChainID())
+	require.Equal(t, fast.server.URL, transport.Current())
+
+	// a failing node is skipped and the call is answered by the next one
+	fast.set(func(node *httpNode) { node.down = true })
+	api := database.NewAPI("database", transport)
+	props, err := api.GetDynamicGlobalProperties()
+	require.Nil(t, err)
+	require.Equal(t, uint32(100), props.HeadBlockNumber)
+	require.Equal(t, slow.server.URL, transport.Current())
+
+	// errors returned by the node itself are not retried elsewhere
+	calls := behind.calls
+	_, err = api.GetBlock(1)
+	require.Error(t, err)
+	require.Equal(t, calls, behind.calls)
+
+	// neither are replies that do not decode
+	var number int
+	err = transport.Call("database", "get_chain_id", []interface{}{}, &number)
+	require.Error(t, err)
+	require.Equal(t, calls, behind.calls)
+	require.Equal(t, slow.server.URL, transport.Current())
+}
+
+func TestFailover_Stall(t *testing.T) {
+	a := newHTTPNode("chain", 100)
+	defer a.server.Close()
+	b := newHTTPNode("chain", 100)
+	defer b.server.Close()
+	b.delay = 20 * time.Millisecond
+
+	options := failover.DefaultOptions()
+	options.ProbeInterval = 50 * time.Millisecond
+	options.StallTimeout = 300 * time.Millisecond
+	transport, err := failover.NewTransportWithOptions([]string{a.server.URL, b.server.URL}, options)
+	require.Nil(t, err)
+	defer transport.Close()
+	require.Equal(t, a.server.URL, transport.Current())
+
+	// only b keeps producing blocks
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(20 * time.Millisecond):
+				b.set(func(node *httpNode) { node.headBlock++ })
+			}
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return transport.Current() == b.server.URL
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestFailover_Websocket(t *testing.T) {
+	node := newWSNode()
+	defer node.close()
+	node.handle("login", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return true, nil
+	})
+	node.handle("database", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return 2, nil
+	})
+	node.handle("get_chain_id", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return "chain", nil
+	})
+	node.handle("get_dynamic_global_properties", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"head_block_number": 100}, nil
+	})
+
+	transport, err := failover.NewTransportWithOptions([]string{node.url()}, failoverOptions())
+	require.Nil(t, err)
+	defer transport.Close()
+
+	// api names are resolved through login_api once per connection
+	api := database.NewAPI("database", transport)
+	_, err = api.GetDynamicGlobalProperties()
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), node.lastAPI("get_dynamic_global_properties"))
+	require.Equal(t, 1, node.requestCount("database"))
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/api/history"
+	"gxclient-go/types"
+	"testing"
+)
+
+const testHistory = `[
+	{"id":"1.11.3","op":[0,{"fee":{"amount":1000,"asset_id":"1.3.1"},"from":"1.2.6","to":"1.2.5","amount":{"amount":200000,"asset_id":"1.3.1"},"extensions":[]}],"result":[0,{}],"block_num":10,"trx_in_block":0,"op_in_trx":0,"virtual_op":1},
+	{"id":"1.11.2","op":[75,{"fee":{"amount":10,"asset_id":"1.3.1"},"account":"1.2.5","contract_id":"1.2.9","method_name":"hi","data":"0102","extensions":[]}],"result":[2,{"amount":5,"asset_id":"1.3.1"}],"block_num":9,"trx_in_block":1,"op_in_trx":0,"virtual_op":2},
+	{"id":"1.11.1","op":[999,{"foo":"bar"}],"result":[1,"1.2.100"],"block_num":8,"trx_in_block":0,"op_in_trx":0,"virtual_op":3}
+]`
+
+func TestHistory_Operation(t *testing.T) {
+	var entries []*history.OperationHistory
+	require.Nil(t, json.Unmarshal([]byte(testHistory), &entries))
+
+	transfer, ok := entries[0].AsTransfer()
+	require.True(t, ok)
+	require.Equal(t, "1.2.5", transfer.To.String())
+	require.Equal(t, uint64(200000), transfer.Amount.Amount)
+	result, err := entries[0].OperationResult()
+	require.Nil(t, err)
+	require.Equal(t, types.VoidResultType, result.Type)
+
+	_, ok = entries[1].AsTransfer()
+	require.False(t, ok)
+	call, ok := entries[1].AsCallContract()
+	require.True(t, ok)
+	require.Equal(t, "1.2.9", call.ContractId.String())
+	result, err = entries[1].OperationResult()
+	require.Nil(t, err)
+	require.Equal(t, types.AssetResultType, result.Type)
+	require.Equal(t, uint64(5), result.Asset.Amount)
+
+	op, err := entries[2].Operation()
+	require.Nil(t, err)
+	unknown, ok := op.(*types.UnknownOperation)
+	require.True(t, ok)
+	require.Equal(t, types.OpType(999), unknown.Type())
+	result, err = entries[2].OperationResult()
+	require.Nil(t, err)
+	require.Equal(t, types.ObjectIDResultType, result.Type)
+	require.Equal(t, "1.2.100", result.ObjectID.String())
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/api/database"
+	"gxclient-go/rpc"
+	"gxclient-go/rpc/http"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHttp_CallBatch(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		posts++
+		var requests []struct {
+			ID     uint64            `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&requests))
+
+		// answer in reverse order, the transport matches responses by id
+		responses := []map[string]interface{}{}
+		for i := len(requests) - 1; i >= 0; i-- {
+			var args []uint32
+			json.Unmarshal(requests[i].Params[2], &args)
+			num := args[0]
+			if num == 13 {
+				responses = append(responses, map[string]interface{}{"id": requests[i].ID, "error": map[string]interface{}{"code": 1, "message": "unlucky"}})
+				continue
+			}
+			responses = append(responses, map[string]interface{}{"id": requests[i].ID, "result": map[string]interface{}{"block_id": fmt.Sprintf("block-%d", num)}})
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	transport := http.NewTransport(server.URL)
+	api := database.NewAPI("database", transport)
+
+	blocks, err := api.GetBlocks(1, 5)
+	require.Nil(t, err)
+	require.Equal(t, 1, posts)
+	require.Len(t, blocks, 5)
+	for i, block := range blocks {
+		require.Equal(t, fmt.Sprintf("block-%d", i+1), block.BlockId)
+	}
+
+	items := []rpc.BatchItem{
+		{API: "database", Method: "get_block", Args: []interface{}{12}, Reply: &database.Block{}},
+		{API: "database", Method: "get_block", Args: []interface{}{13}, Reply: &database.Block{}},
+	}
+	require.Nil(t, transport.CallBatch(items))
+	require.Nil(t, items[0].Error)
+	require.Equal(t, "block-12", items[0].Reply.(*database.Block).BlockId)
+	require.Error(t, items[1].Error)
+}
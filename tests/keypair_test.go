@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gxclient-go/keypair"
 	"gxclient-go/types"
+	"strings"
 	"testing"
 )
 
@@ -38,3 +39,82 @@ func Test_Simple(t *testing.T) {
 	fmt.Println(pub1)
 	fmt.Println(pub2)
 }
+
+// keyFinder answers the accounts of the public keys it knows
+type keyFinder map[string][]string
+
+func (f keyFinder) GetAccountsByPublicKey(publicKey string) ([]string, error) {
+	return f[publicKey], nil
+}
+
+func TestKeypair_DeriveAccountKeys(t *testing.T) {
+	brainKey := "  legal winner thank year\twave sausage worth useful legal winner thank yellow "
+	require.Equal(t, "LEGAL WINNER THANK YEAR WAVE SAUSAGE WORTH USEFUL LEGAL WINNER THANK YELLOW", keypair.NormalizeBrainKey(brainKey))
+	require.True(t, keypair.IsValidMnemonic(brainKey))
+	require.True(t, keypair.IsValidMnemonic(keypair.NormalizeBrainKey(brainKey)))
+	require.False(t, keypair.IsValidMnemonic("legal winner thank year wave sausage worth useful legal winner thank gxchain"))
+	require.False(t, keypair.IsValidMnemonic(strings.Repeat("abandon ", 12)))
+	require.True(t, keypair.IsValidMnemonic(strings.Repeat("abandon ", 11)+"about"))
+
+	keys, err := keypair.DeriveAccountKeys(brainKey)
+	require.Nil(t, err)
+	same, err := keypair.DeriveAccountKeys(keypair.NormalizeBrainKey(brainKey))
+	require.Nil(t, err)
+	require.Equal(t, keys.Owner.ToWIF(), same.Owner.ToWIF())
+
+	owner, err := types.NewPrivateKeyFromBrainKey(keypair.NormalizeBrainKey(brainKey), "0")
+	require.Nil(t, err)
+	require.Equal(t, owner.ToWIF(), keys.Owner.ToWIF())
+	active, err := types.NewPrivateKeyFromBrainKey(owner.ToWIF(), "0")
+	require.Nil(t, err)
+	require.Equal(t, active.ToWIF(), keys.Active.ToWIF())
+	require.NotEqual(t, keys.Owner.ToWIF(), keys.Active.ToWIF())
+	require.NotEqual(t, keys.Active.ToWIF(), keys.Memo.ToWIF())
+
+	next, err := keypair.DeriveAccountKeysAt(brainKey, 1)
+	require.Nil(t, err)
+	require.NotEqual(t, keys.Owner.ToWIF(), next.Owner.ToWIF())
+
+	_, err = keypair.DeriveAccountKeys(" \t")
+	require.NotNil(t, err)
+
+	// sequences 0 and 3 are registered, 1 and 2 are not
+	third, err := keypair.DeriveAccountKeysAt(brainKey, 3)
+	require.Nil(t, err)
+	finder := keyFinder{
+		keys.Owner.PublicKey().String():  {"1.2.20"},
+		keys.Active.PublicKey().String(): {"1.2.20", "1.2.21"},
+		third.Memo.PublicKey().String():  {"1.2.22"},
+	}
+
+	found, err := keypair.ScanAccountKeys(finder, brainKey, 2)
+	require.Nil(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, []string{"1.2.20", "1.2.21"}, found[0].Accounts)
+
+	found, err = keypair.ScanAccountKeys(finder, brainKey, 3)
+	require.Nil(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, uint32(3), found[1].Sequence)
+	require.Equal(t, []string{"1.2.22"}, found[1].Accounts)
+	require.False(t, found[0].Legacy)
+
+	// an account registered with the key of GenerateKeyPair
+	mnemonic := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	pair, err := keypair.GenerateKeyPair(mnemonic)
+	require.Nil(t, err)
+	finder[pair.PrivateKey.PublicKey().String()] = []string{"1.2.23"}
+	found, err = keypair.ScanAccountKeys(finder, mnemonic, 3)
+	require.Nil(t, err)
+	require.Len(t, found, 3)
+	require.True(t, found[0].Legacy)
+	require.Equal(t, []string{"1.2.23"}, found[0].Accounts)
+	require.Equal(t, pair.PrivateKey.ToWIF(), found[0].Active.ToWIF())
+	require.Equal(t, []string{"1.2.20", "1.2.21"}, found[1].Accounts)
+
+	// the normalized brain key gives other keys than GenerateKeyPair
+	found, err = keypair.ScanAccountKeys(finder, keypair.NormalizeBrainKey(mnemonic), 3)
+	require.Nil(t, err)
+	require.Len(t, found, 2)
+	require.False(t, found[0].Legacy)
+}
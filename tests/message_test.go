@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/keypair"
+	"gxclient-go/sign"
+	"gxclient-go/types"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_SignMessage(t *testing.T) {
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+	other, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+
+	var mutex sync.Mutex
+	var head, irreversible uint32
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, &mutex, &callbackID)
+	defer node.close()
+	memoKey := key.PublicKey().String()
+	node.handle("get_account_by_name", func(conn int, args []json.RawMessage) (interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return map[string]interface{}{
+			"id":      "1.2.5",
+			"name":    "test",
+			"options": map[string]interface{}{"memo_key": memoKey, "voting_account": "1.2.5", "votes": []string{}, "extensions": []interface{}{}},
+			"active":  map[string]interface{}{"weight_threshold": 1, "key_auths": [][]interface{}{{other.PrivateKey.PublicKey().String(), 1}}, "account_auths": []interface{}{}, "address_auths": []interface{}{}},
+		}, nil
+	})
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	armored, err := client.SignMessage("login to example.com")
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(armored, "-----BEGIN GXCHAIN SIGNED MESSAGE-----\nlogin to example.com\n-----BEGIN META-----\naccount=test\n"))
+
+	m, err := client.VerifyMessage(armored, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, "login to example.com", m.Message)
+	require.Equal(t, "test", m.Account)
+	require.Equal(t, memoKey, m.PublicKey)
+	require.NotZero(t, m.Nonce)
+	require.WithinDuration(t, time.Now(), m.Timestamp, time.Minute)
+
+	second, err := client.SignMessage("login to example.com")
+	require.Nil(t, err)
+	require.NotEqual(t, armored, second)
+
+	// tampered message
+	_, err = client.VerifyMessage(strings.Replace(armored, "example.com", "example.org", 1), time.Minute)
+	require.NotNil(t, err)
+
+	// too old
+	old := &sign.SignedMessage{Message: "hi", Account: "test", PublicKey: memoKey, Timestamp: time.Now().Add(-time.Hour), Nonce: 1}
+	require.Nil(t, sign.SignMessage(old, key.ToECDSA()))
+	_, err = client.VerifyMessage(old.String(), time.Minute)
+	require.NotNil(t, err)
+	_, err = client.VerifyMessage(old.String(), 0)
+	require.Nil(t, err)
+
+	// an active key is accepted, a key of no authority is not
+	active := &sign.SignedMessage{Message: "hi", Account: "test", PublicKey: other.PrivateKey.PublicKey().String(), Timestamp: time.Now()}
+	require.Nil(t, sign.SignMessage(active, other.PrivateKey.ToECDSA()))
+	_, err = client.VerifyMessage(active.String(), time.Minute)
+	require.Nil(t, err)
+
+	unknown, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+	stranger := &sign.SignedMessage{Message: "hi", Account: "test", PublicKey: unknown.PrivateKey.PublicKey().String(), Timestamp: time.Now()}
+	require.Nil(t, sign.SignMessage(stranger, unknown.PrivateKey.ToECDSA()))
+	_, err = client.VerifyMessage(stranger.String(), time.Minute)
+	require.NotNil(t, err)
+
+	// the key in the meta must be the signing key
+	stranger.PublicKey = memoKey
+	_, err = client.VerifyMessage(stranger.String(), time.Minute)
+	require.NotNil(t, err)
+}
+
+func TestSign_ParseSignedMessage(t *testing.T) {
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+
+	// meta lines written by other wallets are covered by the signature
+	content := "multi\nline\n-----BEGIN META-----\naccount=test\nmemokey=" + key.PublicKey().String() + "\nblock=123\ntimestamp=Sun Oct 18 2026"
+	digest := sha256.Sum256([]byte(content))
+	sig := hex.EncodeToString(sign.SignBufferSha256(digest[:], key.ToECDSA()))
+	armored := "-----BEGIN GXCHAIN SIGNED MESSAGE-----\n" + content + "\n-----BEGIN SIGNATURE-----\n" + sig + "\n-----END GXCHAIN SIGNED MESSAGE-----\n"
+
+	parsed, err := sign.ParseSignedMessage(armored)
+	require.Nil(t, err)
+	require.Equal(t, "multi\nline", parsed.Message)
+	require.Equal(t, "test", parsed.Account)
+	require.True(t, parsed.Timestamp.IsZero())
+	require.Equal(t, content, parsed.Content())
+	require.Nil(t, sign.VerifyMessage(key.PublicKey().ToECDSA(), parsed.Content(), parsed.Signature))
+
+	_, err = sign.ParseSignedMessage("hello")
+	require.NotNil(t, err)
+
+	malformed := []string{
+		// empty meta
+		"-----BEGIN GXCHAIN SIGNED MESSAGE-----\nhi\n-----BEGIN META-----\n-----BEGIN SIGNATURE-----\n" + sig + "\n-----END GXCHAIN SIGNED MESSAGE-----",
+		"-----BEGIN GXCHAIN SIGNED MESSAGE-----\nhi\n-----BEGIN META-----\n\n-----BEGIN SIGNATURE-----\n" + sig + "\n-----END GXCHAIN SIGNED MESSAGE-----",
+		// signature before the meta
+		"-----BEGIN GXCHAIN SIGNED MESSAGE-----\nhi\n-----BEGIN SIGNATURE-----\n" + sig + "\n-----BEGIN META-----\naccount=test\n-----END GXCHAIN SIGNED MESSAGE-----",
+		// last line is not the end of the message
+		"-----BEGIN GXCHAIN SIGNED MESSAGE-----\n" + content + "\n-----BEGIN SIGNATURE-----\n" + sig + "\n-----BEGIN GXCHAIN SIGNED MESSAGE-----",
+	}
+	for _, armored := range malformed {
+		_, err = sign.ParseSignedMessage(armored)
+		require.NotNil(t, err, armored)
+	}
+}
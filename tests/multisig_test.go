@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/api/database"
+	"gxclient-go/keypair"
+	"gxclient-go/types"
+	"testing"
+)
+
+func multisigTx() *types.SignedTransaction {
+	return types.NewSignedTransaction(&types.Transaction{
+		RefBlockNum:    1,
+		RefBlockPrefix: 2,
+		Expiration:     testTime,
+		Operations: types.Operations{
+			types.NewTransferOperation(types.MustParseObjectID("1.2.17"), types.MustParseObjectID("1.2.18"), testAmount, testFee, nil),
+		},
+	})
+}
+
+func TestMultisig_AppendAndMerge(t *testing.T) {
+	second, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+
+	all := multisigTx()
+	require.Nil(t, all.Sign([]string{testPri, second.PrivateKey.ToWIF()}, testChainID))
+
+	first := multisigTx()
+	require.Nil(t, first.AppendSignature(testPri, testChainID))
+	require.Nil(t, first.AppendSignature(testPri, testChainID))
+	require.Len(t, first.Signatures, 1)
+
+	other := multisigTx()
+	require.Nil(t, other.AppendSignature(second.PrivateKey.ToWIF(), testChainID))
+
+	require.Nil(t, first.MergeSignatures(other))
+	require.Nil(t, first.MergeSignatures(other))
+	require.Equal(t, all.Signatures, first.Signatures)
+
+	different := multisigTx()
+	different.RefBlockNum = 3
+	require.NotNil(t, first.MergeSignatures(different))
+}
+
+func TestMultisig_PartiallySignedTransaction(t *testing.T) {
+	second, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+
+	partial := types.NewPartiallySignedTransaction(multisigTx(), testChainID)
+	data, err := json.Marshal(partial)
+	require.Nil(t, err)
+	require.Contains(t, string(data), `"signatures":[]`)
+
+	// each party signs its own copy
+	alice, err := types.ParsePartiallySignedTransaction(data)
+	require.Nil(t, err)
+	require.Nil(t, alice.Sign(testPri))
+	bob, err := types.ParsePartiallySignedTransaction(data)
+	require.Nil(t, err)
+	require.Nil(t, bob.Sign(second.PrivateKey.ToWIF()))
+
+	data, err = json.Marshal(bob)
+	require.Nil(t, err)
+	bob, err = types.ParsePartiallySignedTransaction(data)
+	require.Nil(t, err)
+	require.Nil(t, alice.Merge(bob))
+
+	all := multisigTx()
+	require.Nil(t, all.Sign([]string{testPri, second.PrivateKey.ToWIF()}, testChainID))
+	require.Equal(t, all.Signatures, alice.Transaction.Signatures)
+
+	bob.ChainID = "c2af30ef9340ff81fd61654295e98a1ff04b23189748f86727d0b26b40bb0ff4"
+	require.NotNil(t, alice.Merge(bob))
+
+	_, err = types.ParsePartiallySignedTransaction([]byte(`{"chain_id":"abc","transaction":{}}`))
+	require.NotNil(t, err)
+}
+
+func TestMultisig_Signatures(t *testing.T) {
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+	pub := key.PublicKey().String()
+
+	caller := newStubCaller()
+	caller.handle("get_potential_signatures", func(args []interface{}) (interface{}, error) {
+		tx := args[0].(*types.Transaction)
+		require.NotNil(t, tx.Signatures)
+		return []string{pub, "GXC6K35Bajw29N4fjP4XADHtJ7bEj2xHJ8CoY2P2s1igXTB5oMBhR"}, nil
+	})
+	caller.handle("get_required_signatures", func(args []interface{}) (interface{}, error) {
+		require.Equal(t, []string{pub}, args[1])
+		return []string{pub}, nil
+	})
+	api := database.NewAPI("database", caller)
+
+	tx := multisigTx().Transaction
+	keys, err := api.GetPotentialSignatures(tx)
+	require.Nil(t, err)
+	require.Len(t, keys, 2)
+	require.Nil(t, tx.Signatures)
+
+	keys, err = api.GetRequiredSignatures(tx, pub)
+	require.Nil(t, err)
+	require.Equal(t, []string{pub}, keys)
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/offline"
+	"gxclient-go/transaction"
+	"gxclient-go/types"
+	"strings"
+	"testing"
+)
+
+const testChainID = "4f7d07969c446f8342033acb3ab2ae5044cbe0fde93db02de75bd17fa8fd84b8"
+
+func TestOffline_ReviewTransaction(t *testing.T) {
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+	memo := &types.Memo{From: *key.PublicKey(), To: *key.PublicKey(), Nonce: 1}
+	require.Nil(t, memo.Encrypt(key, "hi"))
+
+	account := types.MustParseObjectID("1.2.17")
+	other := types.MustParseObjectID("1.2.18")
+	token := types.AssetAmount{Amount: 1234, AssetID: types.MustParseObjectID("1.3.7")}
+	tx := &types.Transaction{
+		RefBlockNum:    1,
+		RefBlockPrefix: 2,
+		Expiration:     testTime,
+		Operations: types.Operations{
+			types.NewTransferOperation(account, other, types.AssetAmount{Amount: 150000, AssetID: types.MustParseObjectID("1.3.1")}, testFee, memo),
+			types.NewCallContractOperation(account, other, "deposit", types.Buffer{}, &token, testFee),
+			types.NewProposalCreateOperation(account, []types.Operation{types.NewTransferOperation(other, account, token, testFee, nil)}, testTime, nil, testFee),
+			types.NewAssetFundFeePoolOperation(account, types.MustParseObjectID("1.3.7"), 500000, testFee),
+		},
+	}
+	var b bytes.Buffer
+	require.Nil(t, transaction.NewEncoder(&b).Encode(tx))
+	unSignedHex := hex.EncodeToString(b.Bytes()) + "00"
+
+	review, err := offline.ReviewTransaction(unSignedHex, testChainID, map[string]offline.Asset{"1.3.7": {Symbol: "TOKEN", Precision: 2}})
+	require.Nil(t, err)
+	require.Equal(t, testChainID, review.ChainID)
+	require.True(t, review.Expired)
+	require.Len(t, review.Operations, 4)
+
+	transfer := review.Operations[0]
+	require.Equal(t, "transfer", transfer.Name)
+	require.Equal(t, "1.2.17", transfer.From)
+	require.Equal(t, "1.2.18", transfer.To)
+	require.Equal(t, "1.50000 GXC", transfer.Amount)
+	require.Equal(t, "0.00100 GXC", transfer.Fee)
+	require.True(t, transfer.HasMemo)
+
+	call := review.Operations[1]
+	require.Equal(t, "deposit", call.Method)
+	require.Equal(t, "12.34 TOKEN", call.Amount)
+
+	proposal := review.Operations[2]
+	require.Len(t, proposal.Nested, 1)
+	require.Equal(t, "1.2.17", proposal.Nested[0].To)
+	require.False(t, proposal.Nested[0].HasMemo)
+
+	fund := review.Operations[3]
+	require.Equal(t, "asset_fund_fee_pool", fund.Name)
+	require.Equal(t, "1.3.7", fund.To)
+	require.Equal(t, "5.00000 GXC", fund.Amount)
+
+	text := review.String()
+	require.Contains(t, text, "#1 transfer")
+	require.Contains(t, text, "memo:")
+	require.Contains(t, text, "#3.1 transfer")
+	require.Contains(t, text, "(EXPIRED)")
+
+	out, err := json.Marshal(review)
+	require.Nil(t, err)
+	require.Contains(t, string(out), `"amount":"1.50000 GXC"`)
+
+	// unknown assets are shown in their smallest unit
+	review, err = offline.ReviewTransaction(unSignedHex, testChainID, nil)
+	require.Nil(t, err)
+	require.Equal(t, "1234 1.3.7", review.Operations[1].Amount)
+
+	// the signature is the one of the reviewed transaction
+	sig, err := offline.OfflineSign(testPri, unSignedHex, testChainID)
+	require.Nil(t, err)
+	signed := types.NewSignedTransaction(tx)
+	require.Nil(t, signed.Sign([]string{testPri}, testChainID))
+	require.Equal(t, signed.Signatures[0], sig)
+}
+
+func TestOffline_ReviewTransactionInvalid(t *testing.T) {
+	tx := &types.Transaction{Expiration: testTime, Operations: types.Operations{
+		types.NewTransferOperation(types.MustParseObjectID("1.2.17"), types.MustParseObjectID("1.2.18"), testAmount, testFee, nil),
+	}}
+	var b bytes.Buffer
+	require.Nil(t, transaction.NewEncoder(&b).Encode(tx))
+	serialized := hex.EncodeToString(b.Bytes())
+
+	_, err := offline.ReviewTransaction(serialized+"00", "abc", nil)
+	require.NotNil(t, err)
+
+	// hidden trailing data
+	_, err = offline.ReviewTransaction(serialized+"0000", testChainID, nil)
+	require.NotNil(t, err)
+
+	_, err = offline.ReviewTransaction(serialized+"01"+strings.Repeat("00", 65), testChainID, nil)
+	require.NotNil(t, err)
+}
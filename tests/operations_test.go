@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/transaction"
+	"gxclient-go/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testFeeHex    = "6400000000000000" + "01"
+	testAmountHex = "e803000000000000" + "01"
+	testTimeHex   = "00105e5f"
+)
+
+var (
+	testFee    = types.AssetAmount{Amount: 100, AssetID: types.MustParseObjectID("1.3.1")}
+	testAmount = types.AssetAmount{Amount: 1000, AssetID: types.MustParseObjectID("1.3.1")}
+	testTime   = types.NewTime(time.Unix(1600000000, 0).UTC())
+)
+
+func testPublicKey(t *testing.T) (*types.PublicKey, string) {
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+	return key.PublicKey(), hex.EncodeToString(key.PublicKey().Bytes())
+}
+
+func testAssetOptions() (types.AssetOptions, string) {
+	options := types.AssetOptions{
+		MaxSupply:         1000000,
+		IssuerPermissions: uint16(types.AssetFlagChargeMarketFee),
+		CoreExchangeRate: types.Price{
+			Base:  types.AssetAmount{Amount: 1, AssetID: types.MustParseObjectID("1.3.0")},
+			Quote: types.AssetAmount{Amount: 1, AssetID: types.MustParseObjectID("1.3.1")},
+		},
+		WhitelistAuthorities: types.ObjectIDs{types.MustParseObjectID("1.2.9"), types.MustParseObjectID("1.2.3")},
+		Description:          "hi",
+	}
+	return options, "40420f0000000000" + "0000" + "0000000000000000" + "0100" + "0000" +
+		"0100000000000000" + "00" + "0100000000000000" + "01" +
+		"020309" + "00" + "00" + "00" + "026869" + "00"
+}
+
+func serializeOperation(t *testing.T, op types.Operation) string {
+	var b bytes.Buffer
+	require.Nil(t, transaction.NewEncoder(&b).Encode(op))
+	return hex.EncodeToString(b.Bytes())
+}
+
+func TestOperations_Serialize(t *testing.T) {
+	account := types.MustParseObjectID("1.2.17")
+	other := types.MustParseObjectID("1.2.18")
+	pub, pubHex := testPublicKey(t)
+	options, optionsHex := testAssetOptions()
+	url := "http"
+	reviewPeriod := uint32(10000)
+	newIssuer := other
+
+	owner := types.Authority{
+		WeightThreshold: 1,
+		AccountAuths:    types.AccountAuthsMap{},
+		KeyAuths:        types.KeyAuthsMap{pub: 1},
+		AddressAuths:    types.AddressAuthsMap{},
+		Extensions:      types.Extensions{},
+	}
+	accountOptions := types.AccountOptions{
+		MemoKey:       *pub,
+		VotingAccount: *types.NewGrapheneID("1.2.5"),
+		Votes:         types.Votes{},
+		Extensions:    types.Extensions{},
+	}
+
+	proposalUpdate := types.NewProposalUpdateOperation(account, types.MustParseObjectID("1.10.3"), testFee)
+	proposalUpdate.ActiveApprovalsToAdd = types.ObjectIDs{other, account}
+	proposalUpdate.KeyApprovalsToAdd = types.PublicKeys{*pub}
+
+	signature := bytes.Repeat([]byte{0xaa}, 65)
+
+	tests := []struct {
+		name     string
+		op       types.Operation
+		expected string
+	}{
+		{"account_update", types.NewAccountUpdateOperation(account, nil, nil, nil, testFee),
+			"06" + testFeeHex + "11" + "00" + "00" + "00" + "00"},
+		{"account_update_owner_options", types.NewAccountUpdateOperation(account, &owner, nil, &accountOptions, testFee),
+			"06" + testFeeHex + "11" +
+				"01" + "01000000" + "00" + "01" + pubHex + "0100" + "00" + "00" +
+				"00" +
+				"01" + pubHex + "05" + "0000" + "0000" + "00" + "00" +
+				"00"},
+		{"account_upgrade", types.NewAccountUpgradeOperation(account, true, testFee),
+			"08" + testFeeHex + "11" + "01" + "00"},
+		{"asset_create", types.NewAssetCreateOperation(account, "TEST", 5, options, testFee),
+			"0a" + testFeeHex + "11" + "0454455354" + "05" + optionsHex + "00" + "00" + "00"},
+		{"asset_update", types.NewAssetUpdateOperation(account, types.MustParseObjectID("1.3.2"), &newIssuer, options, testFee),
+			"0b" + testFeeHex + "11" + "02" + "0112" + optionsHex + "00"},
+		{"asset_issue", types.NewAssetIssueOperation(account, other, types.AssetAmount{Amount: 1000, AssetID: types.MustParseObjectID("1.3.2")}, testFee, nil),
+			"0e" + testFeeHex + "11" + "e803000000000000" + "02" + "12" + "00" + "00"},
+		{"asset_reserve", types.NewAssetReserveOperation(account, types.AssetAmount{Amount: 1000, AssetID: types.MustParseObjectID("1.3.2")}, testFee),
+			"0f" + testFeeHex + "11" + "e803000000000000" + "02" + "00"},
+		{"asset_fund_fee_pool", types.NewAssetFundFeePoolOperation(account, types.MustParseObjectID("1.3.2"), 1000, testFee),
+			"10" + testFeeHex + "11" + "02" + "e803000000000000" + "00"},
+		{"proposal_create", types.NewProposalCreateOperation(account, []types.Operation{types.NewTransferOperation(account, other, testAmount, testFee, nil)}, testTime, &reviewPeriod, testFee),
+			"16" + testFeeHex + "11" +
+				"01" + "00" + testFeeHex + "11" + "12" + testAmountHex + "00" + "00" +
+				testTimeHex + "01" + "10270000" + "00"},
+		{"proposal_update", proposalUpdate,
+			"17" + testFeeHex + "11" + "03" + "021112" + "00" + "00" + "00" + "01" + pubHex + "00" + "00"},
+		{"proposal_delete", types.NewProposalDeleteOperation(account, types.MustParseObjectID("1.10.3"), true, testFee),
+			"18" + testFeeHex + "11" + "01" + "03" + "00"},
+		{"vesting_balance_create", types.NewVestingBalanceCreateOperation(account, other, testAmount,
+			types.VestingPolicyInitializer{Type: types.VestingPolicyTypeLinear, Policy: &types.LinearVestingPolicyInitializer{BeginTimestamp: testTime, VestingDurationSeconds: 86400}}, testFee),
+			"20" + testFeeHex + "11" + "12" + testAmountHex + "00" + testTimeHex + "00000000" + "80510100"},
+		{"vesting_balance_withdraw", types.NewVestingBalanceWithdrawOperation(types.MustParseObjectID("1.13.4"), account, testAmount, testFee),
+			"21" + testFeeHex + "04" + "11" + testAmountHex},
+		{"balance_lock", types.NewBalanceLockOperation(account, testTime, "1", testAmount, 30, 500, "", testFee),
+			"47" + testFeeHex + "11" + testTimeHex + "0131" + testAmountHex + "1e000000" + "f4010000" + "00" + "00"},
+		{"balance_unlock", types.NewBalanceUnlockOperation(account, types.MustParseObjectID("1.17.7"), testFee),
+			"48" + testFeeHex + "11" + "07" + "00"},
+		{"proxy_transfer", types.NewProxyTransferOperation(types.ProxyTransferParams{
+			From: account, To: other, ProxyAccount: types.MustParseObjectID("1.2.19"), Amount: testAmount,
+			Percentage: 100, Memo: "hi", Expiration: testTime, Signatures: []types.Buffer{signature},
+		}, testFee),
+			"49" + testFeeHex + "11" + "12" + "13" + testAmountHex + "6400" + "026869" + testTimeHex +
+				"01" + strings.Repeat("aa", 65) + "00"},
+		{"inline_transfer", types.NewInlineTransferOperation(account, other, testAmount, "hi", testFee),
+			"4e" + testFeeHex + "11" + "12" + testAmountHex + "026869" + "00"},
+		{"witness_create", types.NewWitnessCreateOperation(account, url, *pub, testFee),
+			"14" + testFeeHex + "11" + "0468747470" + pubHex},
+		{"witness_update", types.NewWitnessUpdateOperation(types.MustParseObjectID("1.6.2"), account, &url, nil, testFee),
+			"15" + testFeeHex + "02" + "11" + "010468747470" + "00"},
+		{"committee_member_create", types.NewCommitteeMemberCreateOperation(account, url, testFee),
+			"1d" + testFeeHex + "11" + "0468747470"},
+		{"committee_member_update", types.NewCommitteeMemberUpdateOperation(types.MustParseObjectID("1.5.1"), account, nil, testFee),
+			"1e" + testFeeHex + "01" + "11" + "00"},
+		{"trust_node_pledge_withdraw", types.NewTrustNodePledgeWithdrawOperation(account, testFee),
+			"4d" + testFeeHex + "11"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, serializeOperation(t, test.op))
+
+			// JSON decodes into the registered type and serializes to the same bytes
+			data, err := json.Marshal(types.Operations{test.op})
+			require.Nil(t, err)
+			var decoded types.Operations
+			require.Nil(t, json.Unmarshal(data, &decoded))
+			require.IsType(t, test.op, decoded[0])
+			require.Equal(t, test.expected, serializeOperation(t, decoded[0]))
+
+			// and so do the serialized bytes
+			raw, err := hex.DecodeString("01" + test.expected)
+			require.Nil(t, err)
+			r := bytes.NewReader(raw)
+			decoded = nil
+			require.Nil(t, transaction.NewDecoder(r).Decode(&decoded))
+			require.Zero(t, r.Len())
+			require.IsType(t, test.op, decoded[0])
+			require.Equal(t, test.expected, serializeOperation(t, decoded[0]))
+		})
+	}
+}
+
+func TestOperations_AccountUpdateFromNode(t *testing.T) {
+	// as sent by a node in blocks, history and proposals
+	data := `[[6, {
+		"fee": {"amount": 1000, "asset_id": "1.3.1"},
+		"account": "1.2.17",
+		"new_options": {
+			"memo_key": "` + testMemoKey + `",
+			"voting_account": "1.2.5",
+			"num_witness": 0,
+			"num_committee": 0,
+			"votes": [],
+			"extensions": []
+		},
+		"extensions": {}
+	}]]`
+
+	var ops types.Operations
+	require.Nil(t, json.Unmarshal([]byte(data), &ops))
+	update, ok := ops[0].(*types.AccountUpdateOperation)
+	require.True(t, ok)
+	require.Equal(t, "1.2.17", update.Account.String())
+	require.Equal(t, testMemoKey, update.NewOptions.MemoKey.String())
+	require.Zero(t, update.Extensions.Length())
+
+	// the empty extensions serialize as a zero length
+	serialized := serializeOperation(t, update)
+	require.True(t, strings.HasPrefix(serialized, "06"+"e803000000000000"+"01"+"11"+"00"+"00"+"01"))
+	require.True(t, strings.HasSuffix(serialized, "00"))
+
+	raw, err := json.Marshal(update)
+	require.Nil(t, err)
+	require.Contains(t, string(raw), `"extensions":{}`)
+}
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/api/database"
+	"gxclient-go/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newSigningNode answers the calls made to build and sign a transaction of the client account 1.2.5,
+// the fee of every operation is 100 GXC and the signed transactions are kept in broadcasts
+func newSigningNode(broadcasts *[]json.RawMessage, mutex *sync.Mutex) *wsNode {
+	var head, irreversible uint32 = 10, 8
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, mutex, &callbackID)
+	node.handle("get_chain_id", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return testChainID, nil
+	})
+	node.handle("get_dynamic_global_properties", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"head_block_number": head, "last_irreversible_block_num": irreversible, "time": "2019-03-01T00:00:00"}, nil
+	})
+	node.handle("get_block", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"previous": "0000000765f3b3bb3e7b6e4f7d6f0c1d2e3f4a5b", "transaction_ids": []string{}}, nil
+	})
+	node.handle("lookup_asset_symbols", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return []interface{}{map[string]interface{}{"id": "1.3.1", "symbol": "GXC", "precision": 5}}, nil
+	})
+	node.handle("get_required_fees", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var ops [][]json.RawMessage
+		json.Unmarshal(args[0], &ops)
+		fee := map[string]interface{}{"amount": 100, "asset_id": "1.3.1"}
+		fees := []interface{}{}
+		for _, op := range ops {
+			if string(op[0]) == "22" {
+				fees = append(fees, []interface{}{fee, []interface{}{fee}})
+			} else {
+				fees = append(fees, fee)
+			}
+		}
+		return fees, nil
+	})
+	node.handle("broadcast_transaction_synchronous", func(conn int, args []json.RawMessage) (interface{}, error) {
+		mutex.Lock()
+		*broadcasts = append(*broadcasts, args[0])
+		mutex.Unlock()
+		return map[string]interface{}{"id": "00", "block_num": 9, "trx_num": 0}, nil
+	})
+	return node
+}
+
+func TestClient_ProposeAndApprove(t *testing.T) {
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newSigningNode(&broadcasts, &mutex)
+	defer node.close()
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	treasury := types.MustParseObjectID("1.2.30")
+	transfer := types.NewTransferOperation(treasury, types.MustParseObjectID("1.2.5"), testAmount, types.AssetAmount{}, nil)
+	expiration := time.Date(2019, 3, 2, 0, 0, 0, 0, time.UTC)
+	result, err := client.ProposeTransaction([]types.Operation{transfer}, expiration, time.Hour, "GXC", true)
+	require.Nil(t, err)
+	require.NotNil(t, result.BroadcastResponse)
+
+	propose := result.SignedTransaction.Operations[0].(*types.ProposalCreateOperation)
+	require.Equal(t, uint64(100), propose.Fee.Amount)
+	require.Equal(t, "1.2.5", propose.FeePayingAccount.String())
+	require.Equal(t, uint32(3600), *propose.ReviewPeriodSeconds)
+	require.Equal(t, uint64(100), propose.ProposedOps[0].Op.(*types.TransferOperation).Fee.Amount)
+
+	// the broadcast transaction decodes to the proposal
+	data, err := json.Marshal(result.SignedTransaction.Transaction)
+	require.Nil(t, err)
+	require.JSONEq(t, string(data), string(broadcasts[0]))
+	encoded, err := result.SignedTransaction.Serialize()
+	require.Nil(t, err)
+	decoded, err := types.NewTransactionFromHex(hex.EncodeToString(encoded))
+	require.Nil(t, err)
+	require.Equal(t, treasury.String(), decoded.Operations[0].(*types.ProposalCreateOperation).ProposedOps[0].Op.(*types.TransferOperation).From.String())
+
+	for _, c := range []struct {
+		approval gxc.ApprovalType
+		check    func(op *types.ProposalUpdateOperation)
+	}{
+		{gxc.ActiveApproval, func(op *types.ProposalUpdateOperation) {
+			require.Equal(t, "1.2.5", op.ActiveApprovalsToAdd[0].String())
+		}},
+		{gxc.OwnerApproval, func(op *types.ProposalUpdateOperation) {
+			require.Equal(t, "1.2.5", op.OwnerApprovalsToAdd[0].String())
+		}},
+		{gxc.KeyApproval, func(op *types.ProposalUpdateOperation) {
+			key, _ := types.NewPrivateKeyFromWif(testPri)
+			require.Equal(t, key.PublicKey().String(), op.KeyApprovalsToAdd[0].String())
+		}},
+	} {
+		result, err := client.ApproveProposal("1.10.3", c.approval, "GXC", false)
+		require.Nil(t, err)
+		op := result.SignedTransaction.Operations[0].(*types.ProposalUpdateOperation)
+		require.Equal(t, "1.10.3", op.Proposal.String())
+		require.Equal(t, uint64(100), op.Fee.Amount)
+		c.check(op)
+	}
+
+	result, err = client.RevokeApproval("1.10.3", gxc.ActiveApproval, "GXC", false)
+	require.Nil(t, err)
+	op := result.SignedTransaction.Operations[0].(*types.ProposalUpdateOperation)
+	require.Empty(t, op.ActiveApprovalsToAdd)
+	require.Equal(t, "1.2.5", op.ActiveApprovalsToRemove[0].String())
+
+	_, err = client.ApproveProposal("1.10.3", gxc.ApprovalType(5), "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.ProposeTransaction(nil, expiration, 0, "GXC", false)
+	require.NotNil(t, err)
+
+	// a node answering without the fee of the operation
+	node.handle("get_required_fees", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return []interface{}{}, nil
+	})
+	_, err = client.ApproveProposal("1.10.3", gxc.ActiveApproval, "GXC", false)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "got 0 fees")
+}
+
+func TestDatabase_GetProposedTransactions(t *testing.T) {
+	caller := newStubCaller()
+	caller.handle("get_proposed_transactions", func(args []interface{}) (interface{}, error) {
+		require.Equal(t, "1.2.30", args[0])
+		return json.RawMessage(`[{
+			"id": "1.10.3",
+			"expiration_time": "2019-03-02T00:00:00",
+			"review_period_time": "2019-03-01T23:00:00",
+			"proposed_transaction": {
+				"ref_block_num": 0,
+				"ref_block_prefix": 0,
+				"expiration": "2019-03-02T00:00:00",
+				"operations": [[0, {"fee": {"amount": 100, "asset_id": "1.3.1"}, "from": "1.2.30", "to": "1.2.5", "amount": {"amount": 1000, "asset_id": "1.3.1"}, "extensions": []}]],
+				"extensions": []
+			},
+			"required_active_approvals": ["1.2.30"],
+			"available_active_approvals": ["1.2.31"],
+			"required_owner_approvals": [],
+			"available_owner_approvals": [],
+			"available_key_approvals": ["GXC6K35Bajw29N4fjP4XADHtJ7bEj2xHJ8CoY2P2s1igXTB5oMBhR"]
+		}]`), nil
+	})
+	api := database.NewAPI("database", caller)
+
+	proposals, err := api.GetProposedTransactions("1.2.30")
+	require.Nil(t, err)
+	require.Len(t, proposals, 1)
+	proposal := proposals[0]
+	require.Equal(t, "1.10.3", proposal.ID.String())
+	require.NotNil(t, proposal.ReviewPeriodTime)
+	require.Equal(t, "1.2.30", proposal.RequiredActiveApprovals[0].String())
+	require.Equal(t, "1.2.31", proposal.AvailableActiveApprovals[0].String())
+	require.Equal(t, "GXC6K35Bajw29N4fjP4XADHtJ7bEj2xHJ8CoY2P2s1igXTB5oMBhR", proposal.AvailableKeyApprovals[0].String())
+	transfer := proposal.ProposedTransaction.Operations[0].(*types.TransferOperation)
+	require.Equal(t, "1.2.5", transfer.To.String())
+	require.Equal(t, uint64(1000), transfer.Amount.Amount)
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/keypair"
+	"gxclient-go/signer"
+	"gxclient-go/types"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// liarSigner claims the public key of one key but signs with another
+type liarSigner struct {
+	claimed *types.PublicKey
+	signer  types.Signer
+}
+
+func (s *liarSigner) PublicKey() *types.PublicKey { return s.claimed }
+
+func (s *liarSigner) Sign(digest []byte) ([]byte, *types.PublicKey, error) {
+	return s.signer.Sign(digest)
+}
+
+func TestSigner_KeySigner(t *testing.T) {
+	local, err := types.NewKeySignerFromWif(testPri)
+	require.Nil(t, err)
+
+	expected := multisigTx()
+	require.Nil(t, expected.Sign([]string{testPri}, testChainID))
+
+	stx := multisigTx()
+	require.Nil(t, stx.SignWith(testChainID, local))
+	require.Equal(t, expected.Signatures, stx.Signatures)
+
+	keys, err := stx.SignerKeys(testChainID)
+	require.Nil(t, err)
+	require.Equal(t, local.PublicKey().String(), keys[0].String())
+}
+
+func TestSigner_RemoteSigner(t *testing.T) {
+	local, err := types.NewKeySignerFromWif(testPri)
+	require.Nil(t, err)
+	other, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+	unknownKey, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+
+	handler := signer.NewHandler(local, &liarSigner{claimed: other.PrivateKey.PublicKey(), signer: local})
+
+	expected := multisigTx()
+	require.Nil(t, expected.SignWith(testChainID, local))
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "signer")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "signer.sock")
+	listener, err := net.Listen("unix", socket)
+	require.Nil(t, err)
+	go http.Serve(listener, handler)
+	defer listener.Close()
+
+	for _, endpoint := range []string{server.URL, "unix://" + socket} {
+		remote, err := signer.NewRemoteSigner(endpoint, local.PublicKey().String())
+		require.Nil(t, err)
+
+		stx := multisigTx()
+		require.Nil(t, stx.SignWith(testChainID, remote))
+		require.Equal(t, expected.Signatures, stx.Signatures)
+
+		// the daemon signs with another key than the one asked for
+		liar, err := signer.NewRemoteSigner(endpoint, other.PrivateKey.PublicKey().String())
+		require.Nil(t, err)
+		require.NotNil(t, multisigTx().SignWith(testChainID, liar))
+
+		// the daemon does not hold the key
+		unknown, err := signer.NewRemoteSigner(endpoint, unknownKey.PrivateKey.PublicKey().String())
+		require.Nil(t, err)
+		_, _, err = unknown.Sign(make([]byte, 32))
+		require.NotNil(t, err)
+	}
+
+	_, err = signer.NewRemoteSigner("tcp://localhost:1", local.PublicKey().String())
+	require.NotNil(t, err)
+	_, err = signer.NewRemoteSigner(server.URL, "GXC1")
+	require.NotNil(t, err)
+}
+
+func TestClient_NewClientWithSigner(t *testing.T) {
+	local, err := types.NewKeySignerFromWif(testPri)
+	require.Nil(t, err)
+	server := httptest.NewServer(signer.NewHandler(local))
+	defer server.Close()
+	remote, err := signer.NewRemoteSigner(server.URL, local.PublicKey().String())
+	require.Nil(t, err)
+
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newSigningNode(&broadcasts, &mutex)
+	defer node.close()
+
+	client, err := gxc.NewClientWithSigner(remote, "", "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	result, err := client.ApproveProposal("1.10.3", gxc.KeyApproval, "GXC", false)
+	require.Nil(t, err)
+	op := result.SignedTransaction.Operations[0].(*types.ProposalUpdateOperation)
+	require.Equal(t, local.PublicKey().String(), op.KeyApprovalsToAdd[0].String())
+
+	keys, err := result.SignedTransaction.SignerKeys(testChainID)
+	require.Nil(t, err)
+	require.Equal(t, local.PublicKey().String(), keys[0].String())
+
+	// without memo key no message is signed
+	_, err = client.SignMessage("hello")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gxclient-go/rpc"
+)
+
+// stubCaller answers rpc calls from canned handlers, so APIs can be tested without a node
+type stubCaller struct {
+	handlers map[string]func(args []interface{}) (interface{}, error)
+	calls    []string
+}
+
+func newStubCaller() *stubCaller {
+	return &stubCaller{handlers: map[string]func(args []interface{}) (interface{}, error){}}
+}
+
+func (s *stubCaller) handle(method string, handler func(args []interface{}) (interface{}, error)) {
+	s.handlers[method] = handler
+}
+
+func (s *stubCaller) Call(api rpc.APIID, method string, args []interface{}, reply interface{}) error {
+	return s.CallContext(context.Background(), api, method, args, reply)
+}
+
+func (s *stubCaller) CallContext(ctx context.Context, api rpc.APIID, method string, args []interface{}, reply interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.calls = append(s.calls, method)
+	handler, ok := s.handlers[method]
+	if !ok {
+		return fmt.Errorf("unexpected call %s", method)
+	}
+	result, err := handler(args)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, reply)
+}
+
+func (s *stubCaller) SetCallback(api rpc.APIID, method string, callback func(raw json.RawMessage)) error {
+	return nil
+}
+
+func (s *stubCaller) Connect() error {
+	return nil
+}
+
+func (s *stubCaller) Close() error {
+	return nil
+}
+
+// callOnlyCaller is a Caller of another module, without CallContext
+type callOnlyCaller struct {
+	stub *stubCaller
+}
+
+func (c callOnlyCaller) Call(api rpc.APIID, method string, args []interface{}, reply interface{}) error {
+	return c.stub.Call(api, method, args, reply)
+}
+
+func (c callOnlyCaller) SetCallback(api rpc.APIID, method string, callback func(raw json.RawMessage)) error {
+	return nil
+}
+
+func (c callOnlyCaller) Connect() error {
+	return nil
+}
@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/api/database"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newChainNode serves the client handshake and a chain whose head and last irreversible block can be moved
+func newChainNode(head, irreversible *uint32, mutex *sync.Mutex, callbackID *uint64) *wsNode {
+	node := newWSNode()
+	apis := map[string]uint64{"database": 2, "history": 3, "network_broadcast": 4}
+	for name, id := range apis {
+		id := id
+		node.handle(name, func(conn int, args []json.RawMessage) (interface{}, error) {
+			return id, nil
+		})
+	}
+	node.handle("get_chain_id", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return "chain", nil
+	})
+	node.handle("get_account_by_name", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"id": "1.2.5", "name": "test"}, nil
+	})
+	node.handle("get_dynamic_global_properties", func(conn int, args []json.RawMessage) (interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return map[string]interface{}{"head_block_number": *head, "last_irreversible_block_num": *irreversible}, nil
+	})
+	node.handle("get_block", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var num uint32
+		json.Unmarshal(args[0], &num)
+		return map[string]interface{}{"block_id": fmt.Sprintf("%08x", num), "transaction_ids": []string{}}, nil
+	})
+	node.handle("set_block_applied_callback", func(conn int, args []json.RawMessage) (interface{}, error) {
+		mutex.Lock()
+		json.Unmarshal(args[0], callbackID)
+		mutex.Unlock()
+		return nil, nil
+	})
+	return node
+}
+
+func receiveBlocks(t *testing.T, blocks <-chan *database.Block, from, to uint32) {
+	for num := from; num <= to; num++ {
+		select {
+		case block := <-blocks:
+			require.Equal(t, fmt.Sprintf("%08x", num), block.BlockId)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("block %d not received", num)
+		}
+	}
+}
+
+func TestClient_SubscribeBlocks(t *testing.T) {
+	var mutex sync.Mutex
+	head, irreversible := uint32(5), uint32(3)
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, &mutex, &callbackID)
+	defer node.close()
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks, err := client.SubscribeBlocks(ctx, 2, false)
+	require.Nil(t, err)
+
+	// backfill up to the head block
+	receiveBlocks(t, blocks, 2, 5)
+
+	// new blocks are fetched when the node applies them
+	mutex.Lock()
+	head = 7
+	mutex.Unlock()
+	node.notify(callbackID, []string{"00000007"})
+	receiveBlocks(t, blocks, 6, 7)
+
+	// blocks applied while the connection is lost are neither skipped nor sent twice
+	mutex.Lock()
+	head = 9
+	mutex.Unlock()
+	node.dropConnections()
+	receiveBlocks(t, blocks, 8, 9)
+	select {
+	case block := <-blocks:
+		t.Fatalf("unexpected block %s", block.BlockId)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	for range blocks {
+	}
+}
+
+func TestClient_SubscribeBlocksIrreversible(t *testing.T) {
+	var mutex sync.Mutex
+	head, irreversible := uint32(5), uint32(3)
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, &mutex, &callbackID)
+	defer node.close()
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	blocks, err := client.SubscribeBlocks(ctx, 0, true)
+	require.Nil(t, err)
+
+	mutex.Lock()
+	head, irreversible = 6, 4
+	mutex.Unlock()
+	node.notify(callbackID, []string{"00000006"})
+	receiveBlocks(t, blocks, 4, 4)
+}
+
+func TestClient_SubscribeBlocksUnsubscribe(t *testing.T) {
+	var mutex sync.Mutex
+	head, irreversible := uint32(5), uint32(3)
+	var callbackID uint64
+	node := newChainNode(&head, &irreversible, &mutex, &callbackID)
+	defer node.close()
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks, err := client.SubscribeBlocks(ctx, 0, false)
+	require.Nil(t, err)
+	cancel()
+	for range blocks {
+	}
+
+	// notices the node still sends for the unset callback are ignored
+	mutex.Lock()
+	id := callbackID
+	mutex.Unlock()
+	node.notify(id, []string{"00000006"})
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 1, node.requestCount("database"))
+
+	// nothing is registered again on reconnect
+	node.dropConnections()
+	require.Eventually(t, func() bool {
+		_, err := client.Database.GetChainId()
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 2, node.requestCount("database"))
+	require.Equal(t, 1, node.requestCount("set_block_applied_callback"))
+}
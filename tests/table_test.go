@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/require"
+	"gxclient-go/api/database"
+	"gxclient-go/types"
+	"testing"
+)
+
+func TestTableRowsIterator(t *testing.T) {
+	caller := newStubCaller()
+	caller.handle("get_contract_account_by_name", func(args []interface{}) (interface{}, error) {
+		return &types.ContractAccountProperties{
+			ID:   types.MustParseObjectID("1.2.100"),
+			Name: "bank",
+			XAbi: types.Abi{
+				Structs: []types.Struct{{Name: "account", Fields: []types.Field{{Name: "owner", Type: "uint64"}, {Name: "balance", Type: "uint64"}}}},
+				Tables:  []types.Table{{Name: "account", IndexType: "i64", KeyNames: []string{"owner"}, KeyTypes: []string{"uint64"}, Type: "account"}},
+			},
+		}, nil
+	})
+
+	// five rows in the table, served two at a time
+	caller.handle("get_table_rows_ex", func(args []interface{}) (interface{}, error) {
+		params := args[2].(database.TableRowsParams)
+		rows := []map[string]interface{}{}
+		for key := params.LowerBound; key < 5 && int64(len(rows)) < params.Limit; key++ {
+			rows = append(rows, map[string]interface{}{"owner": key, "balance": key * 10})
+		}
+		return map[string]interface{}{"rows": rows, "more": len(rows) > 0 && rows[len(rows)-1]["owner"].(int64) < 4}, nil
+	})
+
+	api := database.NewAPI("database", caller)
+	params := database.NewTableRowsParams()
+	params.Limit = 2
+	it, err := api.NewTableRowsIterator("bank", "account", params)
+	require.Nil(t, err)
+
+	var balances []uint64
+	for it.Next() {
+		var row struct {
+			Owner   uint64 `json:"owner"`
+			Balance uint64 `json:"balance"`
+		}
+		require.Nil(t, it.Decode(&row))
+		balances = append(balances, row.Balance)
+	}
+	require.Nil(t, it.Err())
+	require.Equal(t, []uint64{0, 10, 20, 30, 40}, balances)
+
+	_, err = api.NewTableRowsIterator("bank", "missing", params)
+	require.Error(t, err)
+}
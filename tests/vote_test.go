@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/api/database"
+	"gxclient-go/types"
+	"sync"
+	"testing"
+)
+
+func TestClient_Vote(t *testing.T) {
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newSigningNode(&broadcasts, &mutex)
+	defer node.close()
+
+	ids := map[string]string{"test": "1.2.5", "w1": "1.2.10", "c1": "1.2.11", "both": "1.2.12", "nobody": "1.2.13", "proxy": "1.2.14"}
+	witnesses := map[string]string{"1.2.10": "1:20", "1.2.12": "1:3"}
+	committee := map[string]string{"1.2.11": "0:21", "1.2.12": "0:30"}
+	node.handle("get_account_by_name", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var name string
+		json.Unmarshal(args[0], &name)
+		if id, ok := ids[name]; ok {
+			return map[string]interface{}{"id": id, "name": name}, nil
+		}
+		return nil, nil
+	})
+	node.handle("get_witness_by_account", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var id string
+		json.Unmarshal(args[0], &id)
+		if vote, ok := witnesses[id]; ok {
+			return map[string]interface{}{"id": "1.6.1", "witness_account": id, "vote_id": vote}, nil
+		}
+		return nil, nil
+	})
+	node.handle("get_committee_member_by_account", func(conn int, args []json.RawMessage) (interface{}, error) {
+		var id string
+		json.Unmarshal(args[0], &id)
+		if vote, ok := committee[id]; ok {
+			return map[string]interface{}{"id": "1.5.1", "committee_member_account": id, "vote_id": vote}, nil
+		}
+		return nil, nil
+	})
+	node.handle("get_accounts", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return []interface{}{map[string]interface{}{
+			"id":      "1.2.5",
+			"name":    "test",
+			"options": map[string]interface{}{"memo_key": "GXC6K35Bajw29N4fjP4XADHtJ7bEj2xHJ8CoY2P2s1igXTB5oMBhR", "voting_account": "1.2.5", "num_witness": 1, "num_committee": 0, "votes": []string{"1:20"}, "extensions": []interface{}{}},
+		}}, nil
+	})
+
+	client, err := gxc.NewClient(testPri, testPri, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	options := func(result *types.TransactionResult) *types.AccountOptions {
+		return result.SignedTransaction.Operations[0].(*types.AccountUpdateOperation).NewOptions
+	}
+	votes := func(o *types.AccountOptions) []string {
+		var s []string
+		for _, v := range o.Votes {
+			s = append(s, v.String())
+		}
+		return s
+	}
+
+	result, err := client.VoteFor([]string{"both", "c1", "w1"}, "GXC", false)
+	require.Nil(t, err)
+	o := options(result)
+	require.Equal(t, []string{"1:3", "1:20", "0:21", "0:30"}, votes(o))
+	require.Equal(t, types.UInt16(2), o.NumWitness)
+	require.Equal(t, types.UInt16(2), o.NumCommittee)
+	require.Equal(t, "1.2.5", o.VotingAccount.String())
+
+	raw, err := result.SignedTransaction.Serialize()
+	require.Nil(t, err)
+	decoded, err := types.NewTransactionFromHex(hex.EncodeToString(raw))
+	require.Nil(t, err)
+	require.Equal(t, votes(o), votes(decoded.Operations[0].(*types.AccountUpdateOperation).NewOptions))
+
+	result, err = client.Unvote([]string{"w1"}, "GXC", false)
+	require.Nil(t, err)
+	o = options(result)
+	require.Empty(t, o.Votes)
+	require.Equal(t, types.UInt16(0), o.NumWitness)
+
+	_, err = client.Unvote([]string{"c1"}, "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.VoteFor([]string{"nobody"}, "GXC", false)
+	require.NotNil(t, err)
+	_, err = client.VoteFor(nil, "GXC", false)
+	require.NotNil(t, err)
+
+	result, err = client.SetProxy("proxy", "GXC", false)
+	require.Nil(t, err)
+	o = options(result)
+	require.Equal(t, "1.2.14", o.VotingAccount.String())
+	require.Equal(t, []string{"1:20"}, votes(o))
+	_, err = client.SetProxy("", "GXC", false)
+	require.NotNil(t, err)
+}
+
+func TestDatabase_Votes(t *testing.T) {
+	caller := newStubCaller()
+	caller.handle("get_witnesses", func(args []interface{}) (interface{}, error) {
+		require.Equal(t, []string{"1.6.1", "1.6.99"}, args[0])
+		return []interface{}{map[string]interface{}{"id": "1.6.1", "witness_account": "1.2.10", "vote_id": "1:20", "total_votes": "1000"}, nil}, nil
+	})
+	caller.handle("get_committee_members", func(args []interface{}) (interface{}, error) {
+		return []interface{}{map[string]interface{}{"id": "1.5.1", "committee_member_account": "1.2.11", "vote_id": "0:21", "total_votes": 500}}, nil
+	})
+	caller.handle("lookup_vote_ids", func(args []interface{}) (interface{}, error) {
+		require.Equal(t, []string{"1:20", "0:21"}, args[0])
+		return []interface{}{
+			map[string]interface{}{"id": "1.6.1", "witness_account": "1.2.10", "vote_id": "1:20"},
+			map[string]interface{}{"id": "1.5.1", "committee_member_account": "1.2.11", "vote_id": "0:21"},
+		}, nil
+	})
+	api := database.NewAPI("database", caller)
+
+	witnesses, err := api.GetWitnesses("1.6.1", "1.6.99")
+	require.Nil(t, err)
+	require.Equal(t, "1.2.10", witnesses[0].WitnessAccount)
+	require.Nil(t, witnesses[1])
+
+	members, err := api.GetCommitteeMembers("1.5.1")
+	require.Nil(t, err)
+	require.Equal(t, "0:21", members[0].VoteId)
+
+	objects, err := api.LookupVoteIDs("1:20", "0:21")
+	require.Nil(t, err)
+	require.Equal(t, "1.2.10", objects[0].WitnessAccount)
+	require.Equal(t, "1.2.11", objects[1].CommitteeMemberAccount)
+
+	require.Equal(t, types.Votes{*types.NewVoteIDV2("1:3"), *types.NewVoteIDV2("0:21")},
+		types.Votes{*types.NewVoteIDV2("0:21"), *types.NewVoteIDV2("1:3"), *types.NewVoteIDV2("0:21")}.Sorted())
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	gxc "gxclient-go"
+	"gxclient-go/keypair"
+	"gxclient-go/types"
+	"gxclient-go/wallet"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testScryptParams keep the tests fast
+var testScryptParams = wallet.ScryptParams{N: 1 << 10, R: 8, P: 1}
+
+func TestWallet_LockUnlock(t *testing.T) {
+	w, err := wallet.NewWithScryptParams("password", testScryptParams)
+	require.Nil(t, err)
+	require.False(t, w.IsLocked())
+
+	key, err := types.NewPrivateKeyFromWif(testPri)
+	require.Nil(t, err)
+	pub, err := w.AddKey("alice", testPri)
+	require.Nil(t, err)
+	require.Equal(t, key.PublicKey().String(), pub.String())
+
+	brain, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+	brainPub, err := w.AddBrainKey("bob", brain.BrainKey)
+	require.Nil(t, err)
+	require.Equal(t, brain.PrivateKey.PublicKey().String(), brainPub.String())
+	_, err = w.AddKey("bob", testPri)
+	require.Nil(t, err)
+	_, err = w.AddKey("bob", testPri)
+	require.Nil(t, err)
+	_, err = w.AddKey("bob", "5K")
+	require.NotNil(t, err)
+
+	dir, err := ioutil.TempDir("", "wallet")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "wallet.json")
+	require.Nil(t, w.Save(path))
+	data, err := ioutil.ReadFile(path)
+	require.Nil(t, err)
+	require.False(t, strings.Contains(string(data), testPri))
+
+	loaded, err := wallet.Load(path)
+	require.Nil(t, err)
+	require.True(t, loaded.IsLocked())
+	require.Equal(t, []string{"alice", "bob"}, loaded.Accounts())
+	require.Equal(t, []string{brainPub.String(), pub.String()}, loaded.PublicKeys("bob"))
+	require.True(t, loaded.HasKey(pub.String()))
+
+	_, err = loaded.PrivateKey(pub.String())
+	require.Equal(t, wallet.ErrLocked, err)
+	_, err = loaded.AddKey("carol", testPri)
+	require.Equal(t, wallet.ErrLocked, err)
+
+	require.Equal(t, wallet.ErrWrongPassword, loaded.Unlock("wrong"))
+	require.Nil(t, loaded.Unlock("password"))
+	got, err := loaded.PrivateKey(brainPub.String())
+	require.Nil(t, err)
+	require.Equal(t, brain.PrivateKey.ToWIF(), got.ToWIF())
+	_, err = loaded.PrivateKey(key.PublicKey().String()[:4])
+	require.Equal(t, wallet.ErrKeyNotFound, err)
+
+	loaded.Lock()
+	require.True(t, loaded.IsLocked())
+
+	// the account list in clear is authenticated
+	var file map[string]interface{}
+	require.Nil(t, json.Unmarshal(data, &file))
+	file["accounts"] = map[string]interface{}{"mallory": []string{pub.String()}}
+	tampered, err := json.Marshal(file)
+	require.Nil(t, err)
+	forged, err := wallet.Parse(tampered)
+	require.Nil(t, err)
+	require.Equal(t, wallet.ErrWrongPassword, forged.Unlock("password"))
+}
+
+func TestClient_NewClientWithWallet(t *testing.T) {
+	w, err := wallet.NewWithScryptParams("password", testScryptParams)
+	require.Nil(t, err)
+	memo, err := keypair.GenerateKeyPair("")
+	require.Nil(t, err)
+	active, err := w.AddKey("test", testPri)
+	require.Nil(t, err)
+	_, err = w.AddKey("test", memo.PrivateKey.ToWIF())
+	require.Nil(t, err)
+
+	var mutex sync.Mutex
+	var broadcasts []json.RawMessage
+	node := newSigningNode(&broadcasts, &mutex)
+	defer node.close()
+	node.handle("get_account_by_name", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"id":      "1.2.5",
+			"name":    "test",
+			"active":  map[string]interface{}{"weight_threshold": 1, "key_auths": [][]interface{}{{active.String(), 1}}, "account_auths": []interface{}{}, "address_auths": []interface{}{}},
+			"options": map[string]interface{}{"memo_key": memo.PrivateKey.PublicKey().String(), "voting_account": "1.2.5", "num_witness": 0, "num_committee": 0, "votes": []interface{}{}, "extensions": []interface{}{}},
+		}, nil
+	})
+
+	client, err := gxc.NewClientWithWallet(w, "test", node.url())
+	require.Nil(t, err)
+	defer client.Close()
+
+	// the memo key of the wallet signs messages
+	armored, err := client.SignMessage("hello")
+	require.Nil(t, err)
+	require.Contains(t, armored, memo.PrivateKey.PublicKey().String())
+
+	result, err := client.ApproveProposal("1.10.3", gxc.KeyApproval, "GXC", false)
+	require.Nil(t, err)
+	keys, err := result.SignedTransaction.SignerKeys(testChainID)
+	require.Nil(t, err)
+	require.Equal(t, active.String(), keys[0].String())
+
+	// the active key is read on each signature
+	w.Lock()
+	_, err = client.ApproveProposal("1.10.3", gxc.KeyApproval, "GXC", false)
+	require.NotNil(t, err)
+	_, err = gxc.NewClientWithWallet(w, "test", node.url())
+	require.Equal(t, wallet.ErrLocked, err)
+}
@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"gxclient-go/api/database"
+	"gxclient-go/api/login"
+	"gxclient-go/rpc/websocket"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebsocket_Reconnect(t *testing.T) {
+	node := newWSNode()
+	defer node.close()
+
+	// the database api gets another id on every connection
+	node.handle("database", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return conn + 1, nil
+	})
+	node.handle("get_chain_id", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return "chain", nil
+	})
+	node.handle("set_block_applied_callback", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+
+	states := make(chan websocket.ConnectionState, 10)
+	options := websocket.DefaultOptions()
+	options.InitialBackoff = 10 * time.Millisecond
+	options.OnStateChange = func(state websocket.ConnectionState, err error) {
+		states <- state
+	}
+	transport, err := websocket.NewTransportWithOptions(node.url(), options)
+	require.Nil(t, err)
+	defer transport.Close()
+
+	databaseAPIID, err := login.NewAPI(transport).Database()
+	require.Nil(t, err)
+	require.Equal(t, "2", string(databaseAPIID))
+	api := database.NewAPI(databaseAPIID, transport)
+	require.Nil(t, transport.SetCallback(databaseAPIID, "set_block_applied_callback", func(raw json.RawMessage) {}))
+
+	node.dropConnections()
+	require.Equal(t, websocket.StateDisconnected, <-states)
+	require.Equal(t, websocket.StateReconnecting, <-states)
+	require.Equal(t, websocket.StateConnected, <-states)
+
+	chainID, err := api.GetChainId()
+	require.Nil(t, err)
+	require.Equal(t, "chain", chainID)
+	require.Equal(t, uint64(3), node.lastAPI("get_chain_id"))
+	require.Equal(t, 2, node.requestCount("database"))
+	require.Equal(t, 2, node.requestCount("set_block_applied_callback"))
+}
+
+func TestWebsocket_CallWhileReconnecting(t *testing.T) {
+	node := newWSNode()
+	defer node.close()
+	node.handle("database", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return 2, nil
+	})
+	node.handle("get_chain_id", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return "chain", nil
+	})
+
+	states := make(chan websocket.ConnectionState, 10)
+	options := websocket.DefaultOptions()
+	options.InitialBackoff = 300 * time.Millisecond
+	options.OnStateChange = func(state websocket.ConnectionState, err error) {
+		states <- state
+	}
+	transport, err := websocket.NewTransportWithOptions(node.url(), options)
+	require.Nil(t, err)
+	defer transport.Close()
+
+	databaseAPIID, err := login.NewAPI(transport).Database()
+	require.Nil(t, err)
+	api := database.NewAPI(databaseAPIID, transport)
+
+	node.dropConnections()
+	require.Equal(t, websocket.StateDisconnected, <-states)
+
+	// calls without a context fail fast while the node is away
+	started := time.Now()
+	_, err = api.GetChainId()
+	require.Equal(t, websocket.ErrNotConnected, err)
+	require.True(t, time.Since(started) < 100*time.Millisecond)
+
+	// calls with a context wait for the reconnect
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	chainID, err := api.WithContext(ctx).GetChainId()
+	require.Nil(t, err)
+	require.Equal(t, "chain", chainID)
+}
+
+func TestWebsocket_ReconnectRestoreFailure(t *testing.T) {
+	node := newWSNode()
+	defer node.close()
+
+	// the node accepts the login of the first connection only
+	node.handle("database", func(conn int, args []json.RawMessage) (interface{}, error) {
+		if conn > 1 {
+			return nil, fmt.Errorf("login rejected")
+		}
+		return 2, nil
+	})
+
+	states := make(chan websocket.ConnectionState, 10)
+	options := websocket.DefaultOptions()
+	options.InitialBackoff = 10 * time.Millisecond
+	options.MaxRetries = 2
+	options.OnStateChange = func(state websocket.ConnectionState, err error) {
+		states <- state
+	}
+	transport, err := websocket.NewTransportWithOptions(node.url(), options)
+	require.Nil(t, err)
+	defer transport.Close()
+
+	_, err = login.NewAPI(transport).Database()
+	require.Nil(t, err)
+
+	// failed restores count as attempts, the transport gives up after MaxRetries
+	node.dropConnections()
+	require.Equal(t, websocket.StateDisconnected, <-states)
+	require.Equal(t, websocket.StateReconnecting, <-states)
+	require.Equal(t, websocket.StateReconnecting, <-states)
+	require.Equal(t, websocket.StateClosed, <-states)
+
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 3, node.requestCount("database"))
+	_, err = login.NewAPI(transport).Database()
+	require.NotNil(t, err)
+}
+
+func TestWebsocket_FailedCallbackNotReplayed(t *testing.T) {
+	node := newWSNode()
+	defer node.close()
+	node.handle("database", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return 2, nil
+	})
+	node.handle("set_block_applied_callback", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("rejected")
+	})
+
+	states := make(chan websocket.ConnectionState, 10)
+	options := websocket.DefaultOptions()
+	options.InitialBackoff = 10 * time.Millisecond
+	options.OnStateChange = func(state websocket.ConnectionState, err error) {
+		states <- state
+	}
+	transport, err := websocket.NewTransportWithOptions(node.url(), options)
+	require.Nil(t, err)
+	defer transport.Close()
+
+	databaseAPIID, err := login.NewAPI(transport).Database()
+	require.Nil(t, err)
+	require.NotNil(t, transport.SetCallback(databaseAPIID, "set_block_applied_callback", func(raw json.RawMessage) {}))
+
+	node.dropConnections()
+	require.Equal(t, websocket.StateDisconnected, <-states)
+	require.Equal(t, websocket.StateReconnecting, <-states)
+	require.Equal(t, websocket.StateConnected, <-states)
+	require.Equal(t, 1, node.requestCount("set_block_applied_callback"))
+}
+
+func TestWebsocket_CallContext(t *testing.T) {
+	node := newWSNode()
+	defer node.close()
+
+	release := make(chan struct{})
+	node.handle("get_block", func(conn int, args []json.RawMessage) (interface{}, error) {
+		<-release
+		return nil, nil
+	})
+	node.handle("get_chain_id", func(conn int, args []json.RawMessage) (interface{}, error) {
+		return "chain", nil
+	})
+
+	transport, err := websocket.NewTransport(node.url())
+	require.Nil(t, err)
+	defer transport.Close()
+	api := database.NewAPI("2", transport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = api.WithContext(ctx).GetBlock(1)
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	// the late reply of the cancelled call is dropped and the connection keeps working
+	close(release)
+	chainID, err := api.GetChainId()
+	require.Nil(t, err)
+	require.Equal(t, "chain", chainID)
+}
+
+func TestAPI_CallerWithoutContext(t *testing.T) {
+	stub := newStubCaller()
+	stub.handle("get_chain_id", func(args []interface{}) (interface{}, error) {
+		return "chain", nil
+	})
+	api := database.NewAPI("2", callOnlyCaller{stub})
+
+	chainID, err := api.GetChainId()
+	require.Nil(t, err)
+	require.Equal(t, "chain", chainID)
+
+	// a done context still prevents the call
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = api.WithContext(ctx).GetChainId()
+	require.Equal(t, context.Canceled, err)
+	require.Len(t, stub.calls, 1)
+}
+
+func TestWebsocket_Pipelining(t *testing.T) {
+	node := newWSNode()
+	defer node.close()
+
+	// every request waits until all of them are in flight, so they
+	// can only complete if the transport does not serialize calls
+	const calls = 20
+	var arrived sync.WaitGroup
+	arrived.Add(calls)
+	node.handle("get_block", func(conn int, args []json.RawMessage) (interface{}, error) {
+		arrived.Done()
+		arrived.Wait()
+		var num uint32
+		json.Unmarshal(args[0], &num)
+		return map[string]interface{}{"block_id": fmt.Sprintf("block-%d", num)}, nil
+	})
+
+	transport, err := websocket.NewTransport(node.url())
+	require.Nil(t, err)
+	defer transport.Close()
+	api := database.NewAPI("2", transport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, calls)
+	ids := make([]string, calls)
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			block, err := api.WithContext(ctx).GetBlock(uint32(i))
+			errs[i] = err
+			if err == nil {
+				ids[i] = block.BlockId
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < calls; i++ {
+		require.Nil(t, errs[i])
+		require.Equal(t, fmt.Sprintf("block-%d", i), ids[i])
+	}
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// wsNode is a minimal websocket node answering json-rpc "call" requests from handlers
+type wsNode struct {
+	server *httptest.Server
+
+	mutex       sync.Mutex
+	connections int
+	conns       []*websocket.Conn
+	requests    []string
+	apis        map[string]uint64
+	handlers    map[string]func(conn int, args []json.RawMessage) (interface{}, error)
+}
+
+func newWSNode() *wsNode {
+	node := &wsNode{
+		apis:     map[string]uint64{},
+		handlers: map[string]func(conn int, args []json.RawMessage) (interface{}, error){},
+	}
+	node.server = httptest.NewServer(websocket.Handler(node.serve))
+	return node
+}
+
+func (node *wsNode) url() string {
+	return "ws" + strings.TrimPrefix(node.server.URL, "http")
+}
+
+func (node *wsNode) handle(method string, handler func(conn int, args []json.RawMessage) (interface{}, error)) {
+	node.mutex.Lock()
+	node.handlers[method] = handler
+	node.mutex.Unlock()
+}
+
+// dropConnections closes every open connection, as a node restart would
+func (node *wsNode) dropConnections() {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	for _, c := range node.conns {
+		c.Close()
+	}
+	node.conns = nil
+}
+
+// notify sends a notice for the callback on every open connection
+func (node *wsNode) notify(callbackID uint64, params interface{}) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	for _, c := range node.conns {
+		websocket.JSON.Send(c, map[string]interface{}{"method": "notice", "params": []interface{}{callbackID, params}})
+	}
+}
+
+func (node *wsNode) requestCount(method string) int {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	n := 0
+	for _, r := range node.requests {
+		if r == method {
+			n++
+		}
+	}
+	return n
+}
+
+// lastAPI returns the api id the method was last called on
+func (node *wsNode) lastAPI(method string) uint64 {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	return node.apis[method]
+}
+
+func (node *wsNode) close() {
+	node.dropConnections()
+	node.server.Close()
+}
+
+func (node *wsNode) serve(ws *websocket.Conn) {
+	node.mutex.Lock()
+	node.connections++
+	conn := node.connections
+	node.conns = append(node.conns, ws)
+	node.mutex.Unlock()
+
+	var sendMutex sync.Mutex
+	for {
+		var request struct {
+			ID     uint64            `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := websocket.JSON.Receive(ws, &request); err != nil {
+			return
+		}
+
+		var api uint64
+		var method string
+		var args []json.RawMessage
+		json.Unmarshal(request.Params[0], &api)
+		json.Unmarshal(request.Params[1], &method)
+		json.Unmarshal(request.Params[2], &args)
+
+		node.mutex.Lock()
+		node.requests = append(node.requests, method)
+		node.apis[method] = api
+		handler := node.handlers[method]
+		node.mutex.Unlock()
+
+		go func(id uint64) {
+			response := map[string]interface{}{"id": id}
+			if handler == nil {
+				response["error"] = map[string]interface{}{"code": 1, "message": fmt.Sprintf("unknown method %s", method)}
+			} else if result, err := handler(conn, args); err != nil {
+				response["error"] = map[string]interface{}{"code": 1, "message": err.Error()}
+			} else {
+				response["result"] = result
+			}
+			sendMutex.Lock()
+			websocket.JSON.Send(ws, response)
+			sendMutex.Unlock()
+		}(request.ID)
+	}
+}
@@ -0,0 +1,164 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"io"
+	"math"
+)
+
+type Decoder struct {
+	r io.Reader
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r}
+}
+
+// ReadByte implements io.ByteReader so that binary.ReadUvarint never reads ahead
+func (decoder *Decoder) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(decoder.r, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (decoder *Decoder) DecodeUVarint() (uint64, error) {
+	i, err := binary.ReadUvarint(decoder)
+	if err != nil {
+		return 0, errors.Wrap(err, "decoder: failed to read uvarint")
+	}
+	return i, nil
+}
+
+// lengthReader is implemented by the in memory readers: bytes.Reader, bytes.Buffer and strings.Reader
+type lengthReader interface {
+	Len() int
+}
+
+// Remaining returns the number of unread bytes, or -1 when the reader cannot tell
+func (decoder *Decoder) Remaining() int {
+	if r, ok := decoder.r.(lengthReader); ok {
+		return r.Len()
+	}
+	return -1
+}
+
+// DecodeLength reads the uvarint length of a sequence whose items take at least one byte each,
+// a length larger than the unread bytes is rejected before anything is allocated for it
+func (decoder *Decoder) DecodeLength() (int, error) {
+	n, err := decoder.DecodeUVarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxInt32 {
+		return 0, errors.Errorf("decoder: length %d is too large", n)
+	}
+	if remaining := decoder.Remaining(); remaining >= 0 && n > uint64(remaining) {
+		return 0, errors.Errorf("decoder: length %d exceeds the %d remaining bytes", n, remaining)
+	}
+	return int(n), nil
+}
+
+func (decoder *Decoder) DecodeLittleEndianUInt64() (uint64, error) {
+	var i uint64
+	err := decoder.DecodeNumber(&i)
+	return i, err
+}
+
+func (decoder *Decoder) DecodeLittleEndianUInt32() (uint32, error) {
+	var i uint32
+	err := decoder.DecodeNumber(&i)
+	return i, err
+}
+
+// DecodeNumber reads a little endian fixed size number into v, which must be a pointer
+func (decoder *Decoder) DecodeNumber(v interface{}) error {
+	if err := binary.Read(decoder.r, binary.LittleEndian, v); err != nil {
+		return errors.Wrapf(err, "decoder: failed to read number: %T", v)
+	}
+	return nil
+}
+
+func (decoder *Decoder) DecodeBool() (bool, error) {
+	b, err := decoder.ReadByte()
+	if err != nil {
+		return false, errors.Wrap(err, "decoder: failed to read bool")
+	}
+	return b != 0, nil
+}
+
+// DecodeBytes reads n bytes, n is checked against the unread bytes before allocating
+func (decoder *Decoder) DecodeBytes(n int) ([]byte, error) {
+	remaining := decoder.Remaining()
+	if n < 0 || remaining >= 0 && n > remaining {
+		return nil, errors.Errorf("decoder: cannot read %d bytes, %d remaining", n, remaining)
+	}
+	if remaining < 0 {
+		// the reader does not tell its length, the buffer grows with the bytes actually read
+		var b bytes.Buffer
+		if _, err := io.CopyN(&b, decoder.r, int64(n)); err != nil {
+			return nil, errors.Wrapf(err, "decoder: failed to read %d bytes", n)
+		}
+		return b.Bytes(), nil
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(decoder.r, b); err != nil {
+		return nil, errors.Wrapf(err, "decoder: failed to read %d bytes", n)
+	}
+	return b, nil
+}
+
+func (decoder *Decoder) DecodeString() (string, error) {
+	n, err := decoder.DecodeLength()
+	if err != nil {
+		return "", errors.Wrap(err, "decoder: failed to read string length")
+	}
+	b, err := decoder.DecodeBytes(n)
+	if err != nil {
+		return "", errors.Wrap(err, "decoder: failed to read string")
+	}
+	return string(b), nil
+}
+
+func (decoder *Decoder) DecodeVarint() (int64, error) {
+	i, err := binary.ReadVarint(decoder)
+	if err != nil {
+		return 0, errors.Wrap(err, "decoder: failed to read varint")
+	}
+	return i, nil
+}
+
+// Decode reads v, which must be a TransactionUnmarshaller or a pointer to a number, bool or string
+func (decoder *Decoder) Decode(v interface{}) error {
+	if unmarshaller, ok := v.(TransactionUnmarshaller); ok {
+		return unmarshaller.UnmarshalTransaction(decoder)
+	}
+
+	switch v := v.(type) {
+	case *int8, *int16, *int32, *int64,
+		*uint8, *uint16, *uint32, *uint64:
+		return decoder.DecodeNumber(v)
+
+	case *string:
+		s, err := decoder.DecodeString()
+		if err != nil {
+			return err
+		}
+		*v = s
+		return nil
+	case *bool:
+		b, err := decoder.DecodeBool()
+		if err != nil {
+			return err
+		}
+		*v = b
+		return nil
+
+	default:
+		return errors.Errorf("decoder: unsupported type (%T) encountered", v)
+	}
+}
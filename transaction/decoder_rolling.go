@@ -0,0 +1,91 @@
+package transaction
+
+// RollingDecoder keeps the first error, once set all the following reads are skipped and return zero values
+type RollingDecoder struct {
+	next *Decoder
+	err  error
+}
+
+func NewRollingDecoder(next *Decoder) *RollingDecoder {
+	return &RollingDecoder{next, nil}
+}
+
+func (decoder *RollingDecoder) DecodeVarint() int64 {
+	if decoder.err != nil {
+		return 0
+	}
+	var i int64
+	i, decoder.err = decoder.next.DecodeVarint()
+	return i
+}
+
+func (decoder *RollingDecoder) DecodeUVarint() uint64 {
+	if decoder.err != nil {
+		return 0
+	}
+	var i uint64
+	i, decoder.err = decoder.next.DecodeUVarint()
+	return i
+}
+
+func (decoder *RollingDecoder) DecodeLength() int {
+	if decoder.err != nil {
+		return 0
+	}
+	var n int
+	n, decoder.err = decoder.next.DecodeLength()
+	return n
+}
+
+func (decoder *RollingDecoder) DecodeBool() bool {
+	if decoder.err != nil {
+		return false
+	}
+	var b bool
+	b, decoder.err = decoder.next.DecodeBool()
+	return b
+}
+
+func (decoder *RollingDecoder) DecodeBytes(n int) []byte {
+	if decoder.err != nil {
+		return nil
+	}
+	var b []byte
+	b, decoder.err = decoder.next.DecodeBytes(n)
+	return b
+}
+
+func (decoder *RollingDecoder) DecodeLittleEndianUInt64() uint64 {
+	if decoder.err != nil {
+		return 0
+	}
+	var i uint64
+	i, decoder.err = decoder.next.DecodeLittleEndianUInt64()
+	return i
+}
+
+func (decoder *RollingDecoder) DecodeLittleEndianUInt32() uint32 {
+	if decoder.err != nil {
+		return 0
+	}
+	var i uint32
+	i, decoder.err = decoder.next.DecodeLittleEndianUInt32()
+	return i
+}
+
+func (decoder *RollingDecoder) Decode(v interface{}) {
+	if decoder.err == nil {
+		decoder.err = decoder.next.Decode(v)
+	}
+}
+
+// SetErr records err unless an earlier error is already kept
+func (decoder *RollingDecoder) SetErr(err error) {
+	if decoder.err == nil {
+		decoder.err = err
+	}
+}
+
+func (decoder *RollingDecoder) Err() error {
+	return decoder.err
+}
@@ -0,0 +1,593 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"gxclient-go/transaction"
+	"gxclient-go/util"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SerializeActionData packs the arguments of a contract action into the data of a call_contract operation.
+// params can be a JSON string, a json.RawMessage, a map or any value encoding/json can marshal.
+func (o Abi) SerializeActionData(action string, params interface{}) ([]byte, error) {
+	typ, err := o.actionType(action)
+	if err != nil {
+		return nil, err
+	}
+
+	if s, ok := params.(string); ok {
+		params = json.RawMessage(s)
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	return o.SerializeType(typ, params)
+}
+
+// DeserializeActionData unpacks the data of a call_contract operation into the arguments of the action
+func (o Abi) DeserializeActionData(action string, data []byte) (map[string]interface{}, error) {
+	typ, err := o.actionType(action)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := o.DeserializeType(typ, data)
+	if err != nil {
+		return nil, err
+	}
+
+	params, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("action %s is not a struct type", action)
+	}
+	return params, nil
+}
+
+// SerializeType packs value as the abi type typ
+func (o Abi) SerializeType(typ string, value interface{}) ([]byte, error) {
+	value, err := normalizeAbiValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := o.encodeType(transaction.NewEncoder(&b), typ, value, 0); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// DeserializeType unpacks data as the abi type typ
+func (o Abi) DeserializeType(typ string, data []byte) (interface{}, error) {
+	r := bytes.NewReader(data)
+	value, err := o.decodeType(transaction.NewDecoder(r), typ, 0)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, errors.Errorf("%d bytes left after decoding %s", r.Len(), typ)
+	}
+	return value, nil
+}
+
+func (o Abi) actionType(action string) (string, error) {
+	for _, a := range o.Actions {
+		if a.Name == action {
+			return a.Type, nil
+		}
+	}
+	return "", errors.Errorf("action %s not found in abi", action)
+}
+
+// FindStruct returns the struct named name, or nil if the abi does not declare it
+func (o Abi) FindStruct(name string) *Struct {
+	for i := range o.Structs {
+		if o.Structs[i].Name == name {
+			return &o.Structs[i]
+		}
+	}
+	return nil
+}
+
+// maxAbiDepth bounds the nesting of types, structs and bases, abis come from the chain and may declare
+// a struct containing itself or a cycle of bases
+const maxAbiDepth = 64
+
+// resolveType follows typedef aliases down to a struct or a base type
+func (o Abi) resolveType(typ string) string {
+	for depth := 0; depth < 32; depth++ {
+		found := false
+		for _, t := range o.Types {
+			if t.NewTypeName == typ {
+				typ = t.Type
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	return typ
+}
+
+func (o Abi) encodeType(enc *transaction.Encoder, typ string, value interface{}, depth int) error {
+	if depth > maxAbiDepth {
+		return errors.Errorf("abi type %s is nested deeper than %d", typ, maxAbiDepth)
+	}
+	typ = o.resolveType(typ)
+
+	if strings.HasSuffix(typ, "?") {
+		if value == nil {
+			return enc.EncodeUVarint(0)
+		}
+		if err := enc.EncodeUVarint(1); err != nil {
+			return err
+		}
+		return o.encodeType(enc, typ[:len(typ)-1], value, depth+1)
+	}
+
+	if strings.HasSuffix(typ, "[]") {
+		items, ok := value.([]interface{})
+		if !ok && value != nil {
+			return errors.Errorf("expected array for %s, got %v", typ, value)
+		}
+		if err := enc.EncodeUVarint(uint64(len(items))); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := o.encodeType(enc, typ[:len(typ)-2], item, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if s := o.FindStruct(typ); s != nil {
+		return o.encodeStruct(enc, s, value, depth+1)
+	}
+
+	return encodeBaseType(enc, typ, value)
+}
+
+func (o Abi) encodeStruct(enc *transaction.Encoder, s *Struct, value interface{}, depth int) error {
+	if depth > maxAbiDepth {
+		return errors.Errorf("abi struct %s is nested deeper than %d", s.Name, maxAbiDepth)
+	}
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("expected object for %s, got %v", s.Name, value)
+	}
+
+	if len(s.Base) > 0 {
+		base := o.FindStruct(o.resolveType(s.Base))
+		if base == nil {
+			return errors.Errorf("base %s of struct %s not found in abi", s.Base, s.Name)
+		}
+		if err := o.encodeStruct(enc, base, value, depth+1); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range s.Fields {
+		v, ok := fields[f.Name]
+		if !ok && !strings.HasSuffix(f.Type, "?") {
+			return errors.Errorf("missing field %s of struct %s", f.Name, s.Name)
+		}
+		if err := o.encodeType(enc, f.Type, v, depth+1); err != nil {
+			return errors.Wrapf(err, "failed to encode field %s.%s", s.Name, f.Name)
+		}
+	}
+	return nil
+}
+
+func (o Abi) decodeType(dec *transaction.Decoder, typ string, depth int) (interface{}, error) {
+	if depth > maxAbiDepth {
+		return nil, errors.Errorf("abi type %s is nested deeper than %d", typ, maxAbiDepth)
+	}
+	typ = o.resolveType(typ)
+
+	if strings.HasSuffix(typ, "?") {
+		present, err := dec.DecodeBool()
+		if err != nil {
+			return nil, err
+		}
+		if !present {
+			return nil, nil
+		}
+		return o.decodeType(dec, typ[:len(typ)-1], depth+1)
+	}
+
+	if strings.HasSuffix(typ, "[]") {
+		n, err := dec.DecodeLength()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := o.decodeType(dec, typ[:len(typ)-2], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	if s := o.FindStruct(typ); s != nil {
+		fields := map[string]interface{}{}
+		if err := o.decodeStruct(dec, s, fields, depth+1); err != nil {
+			return nil, err
+		}
+		return fields, nil
+	}
+
+	return decodeBaseType(dec, typ)
+}
+
+func (o Abi) decodeStruct(dec *transaction.Decoder, s *Struct, fields map[string]interface{}, depth int) error {
+	if depth > maxAbiDepth {
+		return errors.Errorf("abi struct %s is nested deeper than %d", s.Name, maxAbiDepth)
+	}
+	if len(s.Base) > 0 {
+		base := o.FindStruct(o.resolveType(s.Base))
+		if base == nil {
+			return errors.Errorf("base %s of struct %s not found in abi", s.Base, s.Name)
+		}
+		if err := o.decodeStruct(dec, base, fields, depth+1); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range s.Fields {
+		v, err := o.decodeType(dec, f.Type, depth+1)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode field %s.%s", s.Name, f.Name)
+		}
+		fields[f.Name] = v
+	}
+	return nil
+}
+
+func encodeBaseType(enc *transaction.Encoder, typ string, value interface{}) error {
+	switch typ {
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return errors.Errorf("expected bool, got %v", value)
+		}
+		return enc.EncodeBool(b)
+	case "int8", "int16", "int32", "int64":
+		bits, _ := strconv.Atoi(typ[3:])
+		i, err := abiInt(value, bits)
+		if err != nil {
+			return err
+		}
+		switch bits {
+		case 8:
+			return enc.EncodeNumber(int8(i))
+		case 16:
+			return enc.EncodeNumber(int16(i))
+		case 32:
+			return enc.EncodeNumber(int32(i))
+		default:
+			return enc.EncodeNumber(i)
+		}
+	case "uint8", "uint16", "uint32", "uint64":
+		bits, _ := strconv.Atoi(typ[4:])
+		u, err := abiUint(value, bits)
+		if err != nil {
+			return err
+		}
+		switch bits {
+		case 8:
+			return enc.EncodeNumber(uint8(u))
+		case 16:
+			return enc.EncodeNumber(uint16(u))
+		case 32:
+			return enc.EncodeNumber(uint32(u))
+		default:
+			return enc.EncodeNumber(u)
+		}
+	case "varint32":
+		i, err := abiInt(value, 32)
+		if err != nil {
+			return err
+		}
+		// zigzag, as fc::signed_int does
+		return enc.EncodeUVarint(uint64((i << 1) ^ (i >> 63)))
+	case "varuint32":
+		u, err := abiUint(value, 32)
+		if err != nil {
+			return err
+		}
+		return enc.EncodeUVarint(u)
+	case "float32", "float64":
+		f, err := abiFloat(value)
+		if err != nil {
+			return err
+		}
+		if typ == "float32" {
+			return enc.EncodeNumber(float32(f))
+		}
+		return enc.EncodeNumber(f)
+	case "name":
+		s, ok := value.(string)
+		if !ok {
+			return errors.Errorf("expected name, got %v", value)
+		}
+		return enc.Encode(util.StringToName(s))
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return errors.Errorf("expected string, got %v", value)
+		}
+		return enc.Encode(s)
+	case "bytes":
+		b, err := abiHex(value, -1)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(Buffer(b))
+	case "checksum160", "checksum256", "checksum512", "signature":
+		b, err := abiHex(value, fixedSizes[typ])
+		if err != nil {
+			return err
+		}
+		return enc.Encode(b)
+	case "public_key":
+		s, ok := value.(string)
+		if !ok {
+			return errors.Errorf("expected public key, got %v", value)
+		}
+		pub, err := NewPublicKeyFromString(s)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(pub)
+	case "time_point_sec":
+		sec, err := abiTimePointSec(value)
+		if err != nil {
+			return err
+		}
+		return enc.EncodeNumber(sec)
+	case "contract_asset":
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("expected contract_asset, got %v", value)
+		}
+		amount, err := abiInt(fields["amount"], 64)
+		if err != nil {
+			return errors.Wrap(err, "contract_asset.amount")
+		}
+		assetID, err := abiUint(fields["asset_id"], 64)
+		if err != nil {
+			return errors.Wrap(err, "contract_asset.asset_id")
+		}
+		if err := enc.EncodeNumber(amount); err != nil {
+			return err
+		}
+		return enc.EncodeNumber(assetID)
+	}
+	return errors.Errorf("unsupported abi type %s", typ)
+}
+
+func decodeBaseType(dec *transaction.Decoder, typ string) (interface{}, error) {
+	switch typ {
+	case "bool":
+		return dec.DecodeBool()
+	case "int8":
+		var i int8
+		err := dec.DecodeNumber(&i)
+		return int64(i), err
+	case "int16":
+		var i int16
+		err := dec.DecodeNumber(&i)
+		return int64(i), err
+	case "int32":
+		var i int32
+		err := dec.DecodeNumber(&i)
+		return int64(i), err
+	case "int64":
+		var i int64
+		err := dec.DecodeNumber(&i)
+		return i, err
+	case "uint8":
+		var u uint8
+		err := dec.DecodeNumber(&u)
+		return uint64(u), err
+	case "uint16":
+		var u uint16
+		err := dec.DecodeNumber(&u)
+		return uint64(u), err
+	case "uint32":
+		var u uint32
+		err := dec.DecodeNumber(&u)
+		return uint64(u), err
+	case "uint64":
+		var u uint64
+		err := dec.DecodeNumber(&u)
+		return u, err
+	case "varint32":
+		u, err := dec.DecodeUVarint()
+		if err != nil {
+			return nil, err
+		}
+		return int64(u>>1) ^ -int64(u&1), nil
+	case "varuint32":
+		return dec.DecodeUVarint()
+	case "float32":
+		var f float32
+		err := dec.DecodeNumber(&f)
+		return float64(f), err
+	case "float64":
+		var f float64
+		err := dec.DecodeNumber(&f)
+		return f, err
+	case "name":
+		var u uint64
+		if err := dec.DecodeNumber(&u); err != nil {
+			return nil, err
+		}
+		return util.NameToString(u), nil
+	case "string":
+		return dec.DecodeString()
+	case "bytes":
+		n, err := dec.DecodeLength()
+		if err != nil {
+			return nil, err
+		}
+		b, err := dec.DecodeBytes(n)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(b), nil
+	case "checksum160", "checksum256", "checksum512", "signature":
+		b, err := dec.DecodeBytes(fixedSizes[typ])
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(b), nil
+	case "public_key":
+		b, err := dec.DecodeBytes(33)
+		if err != nil {
+			return nil, err
+		}
+		pub, err := NewPublicKeyFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		return pub.String(), nil
+	case "time_point_sec":
+		var sec uint32
+		if err := dec.DecodeNumber(&sec); err != nil {
+			return nil, err
+		}
+		return time.Unix(int64(sec), 0).UTC().Format("2006-01-02T15:04:05"), nil
+	case "contract_asset":
+		var amount int64
+		var assetID uint64
+		if err := dec.DecodeNumber(&amount); err != nil {
+			return nil, err
+		}
+		if err := dec.DecodeNumber(&assetID); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"amount": amount, "asset_id": assetID}, nil
+	}
+	return nil, errors.Errorf("unsupported abi type %s", typ)
+}
+
+var fixedSizes = map[string]int{
+	"checksum160": 20,
+	"checksum256": 32,
+	"checksum512": 64,
+	"signature":   65,
+}
+
+// normalizeAbiValue turns any JSON-like input into the generic values the serializer walks
+func normalizeAbiValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal abi value")
+	}
+
+	var out interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&out); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal abi value: %s", string(raw))
+	}
+	return out, nil
+}
+
+func abiInt(value interface{}, bits int) (int64, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, errors.Errorf("expected int%d, got %v", bits, value)
+	}
+	i, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid int%d %s", bits, s)
+	}
+	return i, nil
+}
+
+func abiUint(value interface{}, bits int) (uint64, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, errors.Errorf("expected uint%d, got %v", bits, value)
+	}
+	u, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid uint%d %s", bits, s)
+	}
+	return u, nil
+}
+
+func abiFloat(value interface{}) (float64, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, errors.Errorf("expected float, got %v", value)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid float %s", s)
+	}
+	return f, nil
+}
+
+// abiHex decodes a hex string, size -1 means any length
+func abiHex(value interface{}, size int) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.Errorf("expected hex string, got %v", value)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid hex %s", s)
+	}
+	if size >= 0 && len(b) != size {
+		return nil, errors.Errorf("expected %d bytes, got %d", size, len(b))
+	}
+	return b, nil
+}
+
+func abiTimePointSec(value interface{}) (uint32, error) {
+	if s, ok := value.(string); ok {
+		if t, err := time.ParseInLocation("2006-01-02T15:04:05", s, time.UTC); err == nil {
+			return uint32(t.Unix()), nil
+		}
+	}
+	u, err := abiUint(value, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(u), nil
+}
@@ -48,3 +48,21 @@ func (p AccountCreateOperation) MarshalTransaction(encoder *transaction.Encoder)
 }
 
 func (op *AccountCreateOperation) Type() OpType { return AccountCreateOpType }
+
+func (p *AccountCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	p.Fee = &AssetAmount{}
+	dec.Decode(p.Fee)
+	p.Registrar = GrapheneID{spaceType: SpaceTypeProtocol, objectType: ObjectTypeAccount}
+	dec.Decode(&p.Registrar)
+	p.Referrer = GrapheneID{spaceType: SpaceTypeProtocol, objectType: ObjectTypeAccount}
+	dec.Decode(&p.Referrer)
+	dec.Decode(&p.ReferrerPercent)
+	dec.Decode(&p.Name)
+	dec.Decode(&p.Owner)
+	dec.Decode(&p.Active)
+	dec.Decode(&p.Options)
+	dec.Decode(&p.Extensions)
+
+	return dec.Err()
+}
@@ -66,6 +66,11 @@ func (p AccountCreateExtensions) Length() int {
 	return fields
 }
 
+// UnmarshalTransaction only accepts empty extensions
+func (p *AccountCreateExtensions) UnmarshalTransaction(dec *transaction.Decoder) error {
+	return decodeEmptyExtensions(dec)
+}
+
 func (p AccountCreateExtensions) MarshalTransaction(enc *transaction.Encoder) error {
 	if err := enc.EncodeUVarint(uint64(p.Length())); err != nil {
 		return errors.Annotate(err, "encode length")
@@ -119,6 +124,11 @@ func (p AccountUpdateExtensions) Length() int {
 	return fields
 }
 
+// UnmarshalTransaction only accepts empty extensions
+func (p *AccountUpdateExtensions) UnmarshalTransaction(dec *transaction.Decoder) error {
+	return decodeEmptyExtensions(dec)
+}
+
 func (p AccountUpdateExtensions) MarshalTransaction(enc *transaction.Encoder) error {
 	if err := enc.EncodeUVarint(uint64(p.Length())); err != nil {
 		return errors.Annotate(err, "encode length")
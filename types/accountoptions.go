@@ -43,3 +43,33 @@ func (p AccountOptions) MarshalTransaction(enc *transaction.Encoder) error {
 
 	return nil
 }
+
+func (p *AccountOptions) UnmarshalTransaction(dec *transaction.Decoder) error {
+	if err := dec.Decode(&p.MemoKey); err != nil {
+		return errors.Annotate(err, "decode MemoKey")
+	}
+
+	p.VotingAccount = GrapheneID{spaceType: SpaceTypeProtocol, objectType: ObjectTypeAccount}
+	if err := dec.Decode(&p.VotingAccount); err != nil {
+		return errors.Annotate(err, "decode VotingAccount")
+	}
+
+	if err := dec.Decode(&p.NumWitness); err != nil {
+		return errors.Annotate(err, "decode NumWitness")
+	}
+
+	if err := dec.Decode(&p.NumCommittee); err != nil {
+		return errors.Annotate(err, "decode NumCommittee")
+	}
+
+	if err := dec.Decode(&p.Votes); err != nil {
+		return errors.Annotate(err, "decode Votes")
+	}
+
+	p.Extensions = Extensions{}
+	if err := dec.Decode(&p.Extensions); err != nil {
+		return errors.Annotate(err, "decode Extensions")
+	}
+
+	return nil
+}
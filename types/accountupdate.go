@@ -0,0 +1,91 @@
+package types
+
+import (
+	"gxclient-go/transaction"
+)
+
+// NewAccountUpdateOperation returns a new instance of AccountUpdateOperation,
+// nil owner, active or options are left unchanged
+func NewAccountUpdateOperation(account ObjectID, owner, active *Authority, newOptions *AccountOptions, fee AssetAmount) *AccountUpdateOperation {
+	op := &AccountUpdateOperation{
+		Fee:        fee,
+		Account:    account,
+		Owner:      owner,
+		Active:     active,
+		NewOptions: newOptions,
+		Extensions: AccountUpdateExtensions{},
+	}
+	return op
+}
+
+// AccountUpdateOperation
+type AccountUpdateOperation struct {
+	Fee        AssetAmount             `json:"fee"`
+	Account    ObjectID                `json:"account"`
+	Owner      *Authority              `json:"owner,omitempty"`
+	Active     *Authority              `json:"active,omitempty"`
+	NewOptions *AccountOptions         `json:"new_options,omitempty"`
+	Extensions AccountUpdateExtensions `json:"extensions"`
+}
+
+func (op *AccountUpdateOperation) Type() OpType { return AccountUpdateOpType }
+
+func (op *AccountUpdateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Account)
+
+	//Owner?
+	if op.Owner != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.Owner)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+
+	//Active?
+	if op.Active != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.Active)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+
+	//NewOptions?
+	if op.NewOptions != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.NewOptions)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+
+	enc.Encode(op.Extensions)
+	return enc.Err()
+}
+
+func (op *AccountUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Account = decodeAccountID(dec)
+
+	//Owner?
+	if dec.DecodeUVarint() == 1 {
+		op.Owner = &Authority{}
+		dec.Decode(op.Owner)
+	}
+
+	//Active?
+	if dec.DecodeUVarint() == 1 {
+		op.Active = &Authority{}
+		dec.Decode(op.Active)
+	}
+
+	//NewOptions?
+	if dec.DecodeUVarint() == 1 {
+		op.NewOptions = &AccountOptions{}
+		dec.Decode(op.NewOptions)
+	}
+	dec.Decode(&op.Extensions)
+	return dec.Err()
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewAccountUpgradeOperation returns a new instance of AccountUpgradeOperation
+func NewAccountUpgradeOperation(account ObjectID, upgradeToLifetimeMember bool, fee AssetAmount) *AccountUpgradeOperation {
+	op := &AccountUpgradeOperation{
+		Fee:                     fee,
+		AccountToUpgrade:        account,
+		UpgradeToLifetimeMember: upgradeToLifetimeMember,
+		Extensions:              []json.RawMessage{},
+	}
+	return op
+}
+
+// AccountUpgradeOperation
+type AccountUpgradeOperation struct {
+	Fee                     AssetAmount       `json:"fee"`
+	AccountToUpgrade        ObjectID          `json:"account_to_upgrade"`
+	UpgradeToLifetimeMember bool              `json:"upgrade_to_lifetime_member"`
+	Extensions              []json.RawMessage `json:"extensions"`
+}
+
+func (op *AccountUpgradeOperation) Type() OpType { return AccountUpgradeOpType }
+
+func (op *AccountUpgradeOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.AccountToUpgrade)
+	enc.EncodeBool(op.UpgradeToLifetimeMember)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *AccountUpgradeOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.AccountToUpgrade = decodeAccountID(dec)
+	op.UpgradeToLifetimeMember = dec.DecodeBool()
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
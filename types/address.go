@@ -44,6 +44,23 @@ func (p Address) MarshalTransaction(enc *transaction.Encoder) error {
 	return enc.Encode(p.Bytes())
 }
 
+func (p *Address) UnmarshalTransaction(dec *transaction.Decoder) error {
+	data, err := dec.DecodeBytes(20)
+	if err != nil {
+		return errors.Annotate(err, "decode address")
+	}
+
+	chk, err := util.Ripemd160Checksum(data)
+	if err != nil {
+		return errors.Annotate(err, "Ripemd160Checksum")
+	}
+
+	p.prefix = "GXC"
+	p.data = data
+	p.checksum = chk
+	return nil
+}
+
 func (p Address) String() string {
 	b := append(p.data, p.checksum...)
 	return fmt.Sprintf("%s%s", p.prefix, base58.Encode(b))
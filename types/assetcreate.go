@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gxclient-go/transaction"
+)
+
+// NewAssetCreateOperation returns a new instance of AssetCreateOperation
+func NewAssetCreateOperation(issuer ObjectID, symbol string, precision uint8, options AssetOptions, fee AssetAmount) *AssetCreateOperation {
+	op := &AssetCreateOperation{
+		Fee:           fee,
+		Issuer:        issuer,
+		Symbol:        symbol,
+		Precision:     precision,
+		CommonOptions: options,
+		Extensions:    []json.RawMessage{},
+	}
+	return op
+}
+
+// AssetCreateOperation, bitasset options are not supported
+type AssetCreateOperation struct {
+	Fee                AssetAmount       `json:"fee"`
+	Issuer             ObjectID          `json:"issuer"`
+	Symbol             string            `json:"symbol"`
+	Precision          uint8             `json:"precision"`
+	CommonOptions      AssetOptions      `json:"common_options"`
+	BitassetOpts       json.RawMessage   `json:"bitasset_opts,omitempty"`
+	IsPredictionMarket bool              `json:"is_prediction_market"`
+	Extensions         []json.RawMessage `json:"extensions"`
+}
+
+func (op *AssetCreateOperation) Type() OpType { return AssetCreateOpType }
+
+func (op *AssetCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	if len(op.BitassetOpts) > 0 && string(op.BitassetOpts) != "null" {
+		return errors.New("bitasset options are not supported")
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Issuer)
+	enc.Encode(op.Symbol)
+	enc.Encode(op.Precision)
+	enc.Encode(op.CommonOptions)
+
+	//BitassetOpts?
+	enc.EncodeUVarint(0)
+	enc.EncodeBool(op.IsPredictionMarket)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *AssetCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Issuer = decodeAccountID(dec)
+	dec.Decode(&op.Symbol)
+	dec.Decode(&op.Precision)
+	dec.Decode(&op.CommonOptions)
+
+	//BitassetOpts?
+	if dec.DecodeUVarint() != 0 {
+		dec.SetErr(errors.New("bitasset options are not supported"))
+	}
+	op.IsPredictionMarket = dec.DecodeBool()
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewAssetFundFeePoolOperation returns a new instance of AssetFundFeePoolOperation
+func NewAssetFundFeePoolOperation(from, asset ObjectID, amount int64, fee AssetAmount) *AssetFundFeePoolOperation {
+	op := &AssetFundFeePoolOperation{
+		Fee:         fee,
+		FromAccount: from,
+		AssetID:     asset,
+		Amount:      Share(amount),
+		Extensions:  []json.RawMessage{},
+	}
+	return op
+}
+
+// AssetFundFeePoolOperation adds Amount of the core asset to the fee pool of the asset
+type AssetFundFeePoolOperation struct {
+	Fee         AssetAmount       `json:"fee"`
+	FromAccount ObjectID          `json:"from_account"`
+	AssetID     ObjectID          `json:"asset_id"`
+	Amount      Share             `json:"amount"`
+	Extensions  []json.RawMessage `json:"extensions"`
+}
+
+func (op *AssetFundFeePoolOperation) Type() OpType { return AssetFundFeePoolOpType }
+
+func (op *AssetFundFeePoolOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.FromAccount)
+	enc.Encode(op.AssetID)
+	enc.EncodeNumber(int64(op.Amount))
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *AssetFundFeePoolOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.FromAccount = decodeAccountID(dec)
+	op.AssetID = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeAsset)
+	var amount int64
+	dec.Decode(&amount)
+	op.Amount = Share(amount)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewAssetIssueOperation returns a new instance of AssetIssueOperation
+func NewAssetIssueOperation(issuer, issueTo ObjectID, amount, fee AssetAmount, memo *Memo) *AssetIssueOperation {
+	op := &AssetIssueOperation{
+		Fee:            fee,
+		Issuer:         issuer,
+		AssetToIssue:   amount,
+		IssueToAccount: issueTo,
+		Memo:           memo,
+		Extensions:     []json.RawMessage{},
+	}
+	return op
+}
+
+// AssetIssueOperation
+type AssetIssueOperation struct {
+	Fee            AssetAmount       `json:"fee"`
+	Issuer         ObjectID          `json:"issuer"`
+	AssetToIssue   AssetAmount       `json:"asset_to_issue"`
+	IssueToAccount ObjectID          `json:"issue_to_account"`
+	Memo           *Memo             `json:"memo,omitempty"`
+	Extensions     []json.RawMessage `json:"extensions"`
+}
+
+func (op *AssetIssueOperation) Type() OpType { return AssetIssueOpType }
+
+func (op *AssetIssueOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Issuer)
+	enc.Encode(op.AssetToIssue)
+	enc.Encode(op.IssueToAccount)
+
+	if op.Memo != nil && op.Memo.Message.Length() > 0 {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.Memo)
+	} else {
+		//Memo?
+		enc.EncodeUVarint(0)
+	}
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *AssetIssueOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Issuer = decodeAccountID(dec)
+	dec.Decode(&op.AssetToIssue)
+	op.IssueToAccount = decodeAccountID(dec)
+
+	//Memo?
+	if dec.DecodeUVarint() == 1 {
+		op.Memo = &Memo{}
+		dec.Decode(op.Memo)
+	}
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
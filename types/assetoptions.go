@@ -0,0 +1,114 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+type AssetFlag uint16
+
+// asset issuer permissions and flags
+const (
+	AssetFlagChargeMarketFee     AssetFlag = 0x01
+	AssetFlagWhiteList           AssetFlag = 0x02
+	AssetFlagOverrideAuthority   AssetFlag = 0x04
+	AssetFlagTransferRestricted  AssetFlag = 0x08
+	AssetFlagDisableForceSettle  AssetFlag = 0x10
+	AssetFlagGlobalSettle        AssetFlag = 0x20
+	AssetFlagDisableConfidential AssetFlag = 0x40
+	AssetFlagWitnessFedAsset     AssetFlag = 0x80
+	AssetFlagCommitteeFedAsset   AssetFlag = 0x100
+)
+
+// AssetOptions are the options of an asset that can be updated by its issuer
+type AssetOptions struct {
+	MaxSupply            int64             `json:"max_supply"`
+	MarketFeePercent     uint16            `json:"market_fee_percent"`
+	MaxMarketFee         int64             `json:"max_market_fee"`
+	IssuerPermissions    uint16            `json:"issuer_permissions"`
+	Flags                uint16            `json:"flags"`
+	CoreExchangeRate     Price             `json:"core_exchange_rate"`
+	WhitelistAuthorities ObjectIDs         `json:"whitelist_authorities"`
+	BlacklistAuthorities ObjectIDs         `json:"blacklist_authorities"`
+	WhitelistMarkets     ObjectIDs         `json:"whitelist_markets"`
+	BlacklistMarkets     ObjectIDs         `json:"blacklist_markets"`
+	Description          string            `json:"description"`
+	Extensions           []json.RawMessage `json:"extensions"`
+}
+
+func (o AssetOptions) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeNumber(o.MaxSupply)
+	enc.EncodeNumber(o.MarketFeePercent)
+	enc.EncodeNumber(o.MaxMarketFee)
+	enc.EncodeNumber(o.IssuerPermissions)
+	enc.EncodeNumber(o.Flags)
+	enc.Encode(o.CoreExchangeRate)
+	enc.Encode(o.WhitelistAuthorities)
+	enc.Encode(o.BlacklistAuthorities)
+	enc.Encode(o.WhitelistMarkets)
+	enc.Encode(o.BlacklistMarkets)
+	enc.Encode(o.Description)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+// MarshalJSON keeps the sets as empty arrays rather than null
+func (o AssetOptions) MarshalJSON() ([]byte, error) {
+	type options AssetOptions
+	copied := options(o)
+	for _, set := range []*ObjectIDs{&copied.WhitelistAuthorities, &copied.BlacklistAuthorities, &copied.WhitelistMarkets, &copied.BlacklistMarkets} {
+		if *set == nil {
+			*set = ObjectIDs{}
+		}
+	}
+	if copied.Extensions == nil {
+		copied.Extensions = []json.RawMessage{}
+	}
+	return json.Marshal(&copied)
+}
+
+// UnmarshalJSON reads the supplies sent as strings by the node
+func (o *AssetOptions) UnmarshalJSON(data []byte) error {
+	type options AssetOptions
+	aux := struct {
+		*options
+		MaxSupply    Share `json:"max_supply"`
+		MaxMarketFee Share `json:"max_market_fee"`
+	}{options: (*options)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	o.MaxSupply = int64(aux.MaxSupply)
+	o.MaxMarketFee = int64(aux.MaxMarketFee)
+	return nil
+}
+
+// HasFlag tells whether flag is set in the flags of the asset
+func (o AssetOptions) HasFlag(flag AssetFlag) bool {
+	return o.Flags&uint16(flag) != 0
+}
+
+// HasPermission tells whether the issuer is permitted to set flag
+func (o AssetOptions) HasPermission(flag AssetFlag) bool {
+	return o.IssuerPermissions&uint16(flag) != 0
+}
+
+func (o *AssetOptions) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&o.MaxSupply)
+	dec.Decode(&o.MarketFeePercent)
+	dec.Decode(&o.MaxMarketFee)
+	dec.Decode(&o.IssuerPermissions)
+	dec.Decode(&o.Flags)
+	dec.Decode(&o.CoreExchangeRate)
+	o.WhitelistAuthorities = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAccount)
+	o.BlacklistAuthorities = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAccount)
+	o.WhitelistMarkets = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAsset)
+	o.BlacklistMarkets = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAsset)
+	dec.Decode(&o.Description)
+	o.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
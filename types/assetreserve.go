@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewAssetReserveOperation returns a new instance of AssetReserveOperation
+func NewAssetReserveOperation(payer ObjectID, amount, fee AssetAmount) *AssetReserveOperation {
+	op := &AssetReserveOperation{
+		Fee:             fee,
+		Payer:           payer,
+		AmountToReserve: amount,
+		Extensions:      []json.RawMessage{},
+	}
+	return op
+}
+
+// AssetReserveOperation
+type AssetReserveOperation struct {
+	Fee             AssetAmount       `json:"fee"`
+	Payer           ObjectID          `json:"payer"`
+	AmountToReserve AssetAmount       `json:"amount_to_reserve"`
+	Extensions      []json.RawMessage `json:"extensions"`
+}
+
+func (op *AssetReserveOperation) Type() OpType { return AssetReserveOpType }
+
+func (op *AssetReserveOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Payer)
+	enc.Encode(op.AmountToReserve)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *AssetReserveOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Payer = decodeAccountID(dec)
+	dec.Decode(&op.AmountToReserve)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
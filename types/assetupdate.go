@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewAssetUpdateOperation returns a new instance of AssetUpdateOperation, a nil newIssuer keeps the issuer
+func NewAssetUpdateOperation(issuer, asset ObjectID, newIssuer *ObjectID, newOptions AssetOptions, fee AssetAmount) *AssetUpdateOperation {
+	op := &AssetUpdateOperation{
+		Fee:           fee,
+		Issuer:        issuer,
+		AssetToUpdate: asset,
+		NewIssuer:     newIssuer,
+		NewOptions:    newOptions,
+		Extensions:    []json.RawMessage{},
+	}
+	return op
+}
+
+// AssetUpdateOperation
+type AssetUpdateOperation struct {
+	Fee           AssetAmount       `json:"fee"`
+	Issuer        ObjectID          `json:"issuer"`
+	AssetToUpdate ObjectID          `json:"asset_to_update"`
+	NewIssuer     *ObjectID         `json:"new_issuer,omitempty"`
+	NewOptions    AssetOptions      `json:"new_options"`
+	Extensions    []json.RawMessage `json:"extensions"`
+}
+
+func (op *AssetUpdateOperation) Type() OpType { return AssetUpdateOpType }
+
+func (op *AssetUpdateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Issuer)
+	enc.Encode(op.AssetToUpdate)
+
+	//NewIssuer?
+	if op.NewIssuer != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.NewIssuer)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+
+	enc.Encode(op.NewOptions)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *AssetUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Issuer = decodeAccountID(dec)
+	op.AssetToUpdate = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeAsset)
+
+	//NewIssuer?
+	if dec.DecodeUVarint() == 1 {
+		newIssuer := decodeAccountID(dec)
+		op.NewIssuer = &newIssuer
+	}
+
+	dec.Decode(&op.NewOptions)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
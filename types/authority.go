@@ -17,6 +17,104 @@ type Authority struct {
 	Extensions      Extensions      `json:"extensions"`
 }
 
+// NewKeyAuthority returns an authority satisfied by the signature of pub alone
+func NewKeyAuthority(pub *PublicKey) Authority {
+	return Authority{
+		WeightThreshold: 1,
+		AccountAuths:    AccountAuthsMap{},
+		KeyAuths:        KeyAuthsMap{pub: 1},
+		AddressAuths:    AddressAuthsMap{},
+		Extensions:      Extensions{},
+	}
+}
+
+// Clone returns a copy of the authority whose auths can be changed without changing p
+func (p Authority) Clone() *Authority {
+	clone := &Authority{
+		WeightThreshold: p.WeightThreshold,
+		AccountAuths:    AccountAuthsMap{},
+		KeyAuths:        KeyAuthsMap{},
+		AddressAuths:    AddressAuthsMap{},
+		Extensions:      Extensions{},
+	}
+	for k, v := range p.AccountAuths {
+		clone.AccountAuths[k] = v
+	}
+	for k, v := range p.KeyAuths {
+		clone.KeyAuths[k] = v
+	}
+	for k, v := range p.AddressAuths {
+		clone.AddressAuths[k] = v
+	}
+	return clone
+}
+
+// SetKey sets the weight of pub, replacing its weight if pub is already a key of the authority
+func (p *Authority) SetKey(pub *PublicKey, weight UInt16) {
+	p.RemoveKey(pub)
+	if p.KeyAuths == nil {
+		p.KeyAuths = KeyAuthsMap{}
+	}
+	p.KeyAuths[pub] = weight
+}
+
+// RemoveKey removes pub from the keys of the authority and tells whether it was there
+func (p *Authority) RemoveKey(pub *PublicKey) bool {
+	for k := range p.KeyAuths {
+		if k.Equal(pub) {
+			delete(p.KeyAuths, k)
+			return true
+		}
+	}
+	return false
+}
+
+// SetAccount sets the weight of account, replacing its weight if account is already an account of the authority
+func (p *Authority) SetAccount(account GrapheneID, weight UInt16) {
+	p.RemoveAccount(account)
+	if p.AccountAuths == nil {
+		p.AccountAuths = AccountAuthsMap{}
+	}
+	p.AccountAuths[account] = weight
+}
+
+// RemoveAccount removes account from the accounts of the authority and tells whether it was there
+func (p *Authority) RemoveAccount(account GrapheneID) bool {
+	for k := range p.AccountAuths {
+		if k.String() == account.String() {
+			delete(p.AccountAuths, k)
+			return true
+		}
+	}
+	return false
+}
+
+// TotalWeight returns the sum of the weights of the auths
+func (p Authority) TotalWeight() uint64 {
+	var total uint64
+	for _, w := range p.KeyAuths {
+		total += uint64(w)
+	}
+	for _, w := range p.AccountAuths {
+		total += uint64(w)
+	}
+	for _, w := range p.AddressAuths {
+		total += uint64(w)
+	}
+	return total
+}
+
+// Validate checks that the weight threshold can be reached, so that the account is not locked out
+func (p Authority) Validate() error {
+	if p.WeightThreshold == 0 {
+		return errors.New("weight threshold of authority is zero")
+	}
+	if total := p.TotalWeight(); total < uint64(p.WeightThreshold) {
+		return errors.Errorf("weight threshold %d of authority is above the total weight %d", p.WeightThreshold, total)
+	}
+	return nil
+}
+
 func (p Authority) MarshalTransaction(enc *transaction.Encoder) error {
 	if err := enc.Encode(p.WeightThreshold); err != nil {
 		return errors.Annotate(err, "encode WeightThreshold")
@@ -41,6 +139,31 @@ func (p Authority) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *Authority) UnmarshalTransaction(dec *transaction.Decoder) error {
+	if err := dec.Decode(&p.WeightThreshold); err != nil {
+		return errors.Annotate(err, "decode WeightThreshold")
+	}
+
+	if err := dec.Decode(&p.AccountAuths); err != nil {
+		return errors.Annotate(err, "decode AccountAuths")
+	}
+
+	if err := dec.Decode(&p.KeyAuths); err != nil {
+		return errors.Annotate(err, "decode KeyAuths")
+	}
+
+	if err := dec.Decode(&p.AddressAuths); err != nil {
+		return errors.Annotate(err, "decode AddressAuths")
+	}
+
+	p.Extensions = Extensions{}
+	if err := dec.Decode(&p.Extensions); err != nil {
+		return errors.Annotate(err, "decode Extensions")
+	}
+
+	return nil
+}
+
 type KeyAuthsMap map[*PublicKey]UInt16
 
 func (p *KeyAuthsMap) UnmarshalJSON(data []byte) error {
@@ -132,6 +255,30 @@ func (p KeyAuthsMap) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *KeyAuthsMap) UnmarshalTransaction(dec *transaction.Decoder) error {
+	n, err := dec.DecodeLength()
+	if err != nil {
+		return errors.Annotate(err, "decode length")
+	}
+
+	(*p) = make(map[*PublicKey]UInt16)
+	for i := 0; i < n; i++ {
+		var pub PublicKey
+		if err := dec.Decode(&pub); err != nil {
+			return errors.Annotate(err, "decode Key")
+		}
+
+		var weight UInt16
+		if err := dec.Decode(&weight); err != nil {
+			return errors.Annotate(err, "decode Weight")
+		}
+
+		(*p)[&pub] = weight
+	}
+
+	return nil
+}
+
 type AddressAuthsMap map[*Address]UInt16
 
 func (p *AddressAuthsMap) UnmarshalJSON(data []byte) error {
@@ -202,6 +349,30 @@ func (p AddressAuthsMap) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *AddressAuthsMap) UnmarshalTransaction(dec *transaction.Decoder) error {
+	n, err := dec.DecodeLength()
+	if err != nil {
+		return errors.Annotate(err, "decode length")
+	}
+
+	(*p) = make(map[*Address]UInt16)
+	for i := 0; i < n; i++ {
+		var addr Address
+		if err := dec.Decode(&addr); err != nil {
+			return errors.Annotate(err, "decode Key")
+		}
+
+		var weight UInt16
+		if err := dec.Decode(&weight); err != nil {
+			return errors.Annotate(err, "decode Value")
+		}
+
+		(*p)[&addr] = weight
+	}
+
+	return nil
+}
+
 type AccountAuthsMap map[GrapheneID]UInt16
 
 func (p *AccountAuthsMap) UnmarshalJSON(data []byte) error {
@@ -255,12 +426,22 @@ func (p AccountAuthsMap) MarshalTransaction(enc *transaction.Encoder) error {
 		return errors.Annotate(err, "encode length")
 	}
 
-	for k, v := range p {
-		if err := enc.Encode(k); err != nil {
+	//sort accounts by instance, the order of the flat_map
+	accounts := make([]interface{}, 0, len(p))
+	for k := range p {
+		accounts = append(accounts, k)
+	}
+	sort.Sort(accounts, func(a, b interface{}) int {
+		return sort.UInt64Comparator(uint64(a.(GrapheneID).instance), uint64(b.(GrapheneID).instance))
+	})
+
+	for _, k := range accounts {
+		account := k.(GrapheneID)
+		if err := enc.Encode(account); err != nil {
 			return errors.Annotate(err, "encode account")
 		}
 
-		if err := enc.Encode(v); err != nil {
+		if err := enc.Encode(p[account]); err != nil {
 			return errors.Annotate(err, "encode Weight")
 		}
 	}
@@ -268,6 +449,30 @@ func (p AccountAuthsMap) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *AccountAuthsMap) UnmarshalTransaction(dec *transaction.Decoder) error {
+	n, err := dec.DecodeLength()
+	if err != nil {
+		return errors.Annotate(err, "decode length")
+	}
+
+	(*p) = make(map[GrapheneID]UInt16)
+	for i := 0; i < n; i++ {
+		account := GrapheneID{spaceType: SpaceTypeProtocol, objectType: ObjectTypeAccount}
+		if err := dec.Decode(&account); err != nil {
+			return errors.Annotate(err, "decode account")
+		}
+
+		var weight UInt16
+		if err := dec.Decode(&weight); err != nil {
+			return errors.Annotate(err, "decode Weight")
+		}
+
+		(*p)[account] = weight
+	}
+
+	return nil
+}
+
 type NoSpecialAuthority struct{}
 
 type TopHoldersSpecialAuthority struct {
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewBalanceLockOperation returns a new instance of BalanceLockOperation
+func NewBalanceLockOperation(account ObjectID, createDateTime Time, programId string, amount AssetAmount, lockDays, interestRate uint32, memo string, fee AssetAmount) *BalanceLockOperation {
+	op := &BalanceLockOperation{
+		Fee:            fee,
+		Account:        account,
+		CreateDateTime: createDateTime,
+		ProgramId:      programId,
+		Amount:         amount,
+		LockDays:       lockDays,
+		InterestRate:   interestRate,
+		Memo:           memo,
+		Extensions:     []json.RawMessage{},
+	}
+	return op
+}
+
+// BalanceLockOperation
+type BalanceLockOperation struct {
+	Fee            AssetAmount       `json:"fee"`
+	Account        ObjectID          `json:"account"`
+	CreateDateTime Time              `json:"create_date_time"`
+	ProgramId      string            `json:"program_id"`
+	Amount         AssetAmount       `json:"amount"`
+	LockDays       uint32            `json:"lock_days"`
+	InterestRate   uint32            `json:"interest_rate"`
+	Memo           string            `json:"memo"`
+	Extensions     []json.RawMessage `json:"extensions"`
+}
+
+func (op *BalanceLockOperation) Type() OpType { return BalanceLockOpType }
+
+func (op *BalanceLockOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Account)
+	enc.Encode(op.CreateDateTime)
+	enc.Encode(op.ProgramId)
+	enc.Encode(op.Amount)
+	enc.Encode(op.LockDays)
+	enc.Encode(op.InterestRate)
+	enc.Encode(op.Memo)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *BalanceLockOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Account = decodeAccountID(dec)
+	dec.Decode(&op.CreateDateTime)
+	dec.Decode(&op.ProgramId)
+	dec.Decode(&op.Amount)
+	dec.Decode(&op.LockDays)
+	dec.Decode(&op.InterestRate)
+	dec.Decode(&op.Memo)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewBalanceUnlockOperation returns a new instance of BalanceUnlockOperation
+func NewBalanceUnlockOperation(account, lockId ObjectID, fee AssetAmount) *BalanceUnlockOperation {
+	op := &BalanceUnlockOperation{
+		Fee:        fee,
+		Account:    account,
+		LockId:     lockId,
+		Extensions: []json.RawMessage{},
+	}
+	return op
+}
+
+// BalanceUnlockOperation
+type BalanceUnlockOperation struct {
+	Fee        AssetAmount       `json:"fee"`
+	Account    ObjectID          `json:"account"`
+	LockId     ObjectID          `json:"lock_id"`
+	Extensions []json.RawMessage `json:"extensions"`
+}
+
+func (op *BalanceUnlockOperation) Type() OpType { return BalanceUnlockOpType }
+
+func (op *BalanceUnlockOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Account)
+	enc.Encode(op.LockId)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *BalanceUnlockOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Account = decodeAccountID(dec)
+	op.LockId = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeLockBalance)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
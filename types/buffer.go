@@ -61,6 +61,21 @@ func (p Buffer) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *Buffer) UnmarshalTransaction(dec *transaction.Decoder) error {
+	n, err := dec.DecodeLength()
+	if err != nil {
+		return errors.Annotate(err, "decode length")
+	}
+
+	b, err := dec.DecodeBytes(n)
+	if err != nil {
+		return errors.Annotate(err, "decode bytes")
+	}
+
+	*p = b
+	return nil
+}
+
 //Encrypt AES-encrypts the buffer content
 func (p *Buffer) Encrypt(cipherKey []byte) ([]byte, error) {
 	block, err := aes.NewCipher(cipherKey)
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"gxclient-go/transaction"
+)
+
+// NewCommitteeMemberCreateOperation returns a new instance of CommitteeMemberCreateOperation
+func NewCommitteeMemberCreateOperation(committeeMemberAccount ObjectID, url string, fee AssetAmount) *CommitteeMemberCreateOperation {
+	op := &CommitteeMemberCreateOperation{
+		Fee:                    fee,
+		CommitteeMemberAccount: committeeMemberAccount,
+		Url:                    url,
+	}
+	return op
+}
+
+// CommitteeMemberCreateOperation
+type CommitteeMemberCreateOperation struct {
+	Fee                    AssetAmount `json:"fee"`
+	CommitteeMemberAccount ObjectID    `json:"committee_member_account"`
+	Url                    string      `json:"url"`
+}
+
+func (op *CommitteeMemberCreateOperation) Type() OpType { return CommitteeMemberCreateOpType }
+
+func (op *CommitteeMemberCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.CommitteeMemberAccount)
+	enc.Encode(op.Url)
+	return enc.Err()
+}
+
+func (op *CommitteeMemberCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.CommitteeMemberAccount = decodeAccountID(dec)
+	dec.Decode(&op.Url)
+	return dec.Err()
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"gxclient-go/transaction"
+)
+
+// NewCommitteeMemberUpdateOperation returns a new instance of CommitteeMemberUpdateOperation, a nil newUrl is left unchanged
+func NewCommitteeMemberUpdateOperation(committeeMember, committeeMemberAccount ObjectID, newUrl *string, fee AssetAmount) *CommitteeMemberUpdateOperation {
+	op := &CommitteeMemberUpdateOperation{
+		Fee:                    fee,
+		CommitteeMember:        committeeMember,
+		CommitteeMemberAccount: committeeMemberAccount,
+		NewUrl:                 newUrl,
+	}
+	return op
+}
+
+// CommitteeMemberUpdateOperation
+type CommitteeMemberUpdateOperation struct {
+	Fee                    AssetAmount `json:"fee"`
+	CommitteeMember        ObjectID    `json:"committee_member"`
+	CommitteeMemberAccount ObjectID    `json:"committee_member_account"`
+	NewUrl                 *string     `json:"new_url,omitempty"`
+}
+
+func (op *CommitteeMemberUpdateOperation) Type() OpType { return CommitteeMemberUpdateOpType }
+
+func (op *CommitteeMemberUpdateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.CommitteeMember)
+	enc.Encode(op.CommitteeMemberAccount)
+
+	//NewUrl?
+	if op.NewUrl != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(*op.NewUrl)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+	return enc.Err()
+}
+
+func (op *CommitteeMemberUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.CommitteeMember = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeCommiteeMember)
+	op.CommitteeMemberAccount = decodeAccountID(dec)
+
+	//NewUrl?
+	if dec.DecodeUVarint() == 1 {
+		op.NewUrl = new(string)
+		dec.Decode(op.NewUrl)
+	}
+	return dec.Err()
+}
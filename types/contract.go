@@ -16,6 +16,13 @@ func (o TypeDef) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+func (o *TypeDef) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&o.NewTypeName)
+	dec.Decode(&o.Type)
+	return dec.Err()
+}
+
 type ErrorMessage struct {
 	ErrorCode uint64 `json:"error_code"`
 	ErrorMsg  string `json:"error_msg"`
@@ -27,6 +34,13 @@ func (o ErrorMessage) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+func (o *ErrorMessage) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&o.ErrorCode)
+	dec.Decode(&o.ErrorMsg)
+	return dec.Err()
+}
+
 type Struct struct {
 	Name   string  `json:"name"`
 	Base   string  `json:"base"`
@@ -43,6 +57,19 @@ func (o Struct) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+func (o *Struct) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&o.Name)
+	dec.Decode(&o.Base)
+
+	n := dec.DecodeLength()
+	o.Fields = make([]Field, n)
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		dec.Decode(&o.Fields[i])
+	}
+	return dec.Err()
+}
+
 type Table struct {
 	Name      string   `json:"name"`
 	IndexType string   `json:"index_type"`
@@ -69,6 +96,28 @@ func (o Table) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+func (o *Table) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	var name uint64
+	dec.Decode(&name)
+	o.Name = util.NameToString(name)
+	dec.Decode(&o.IndexType)
+	o.KeyNames = decodeStrings(dec)
+	o.KeyTypes = decodeStrings(dec)
+	dec.Decode(&o.Type)
+	return dec.Err()
+}
+
+// decodeStrings reads a length prefixed list of strings
+func decodeStrings(dec *transaction.RollingDecoder) []string {
+	n := dec.DecodeLength()
+	items := make([]string, n)
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		dec.Decode(&items[i])
+	}
+	return items
+}
+
 type Field struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
@@ -80,6 +129,13 @@ func (o Field) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+func (o *Field) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&o.Name)
+	dec.Decode(&o.Type)
+	return dec.Err()
+}
+
 type Action struct {
 	Name    string `json:"name"`
 	Type    string `json:"type"`
@@ -94,6 +150,16 @@ func (o Action) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+func (o *Action) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	var name uint64
+	dec.Decode(&name)
+	o.Name = util.NameToString(name)
+	dec.Decode(&o.Type)
+	dec.Decode(&o.Payable)
+	return dec.Err()
+}
+
 type Abi struct {
 	Version       string         `json:"version"`
 	Types         []TypeDef      `json:"types"`
@@ -132,6 +198,82 @@ func (o Abi) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+// UnmarshalTransaction reads an abi with empty abi extensions
+func (o *Abi) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&o.Version)
+
+	n := dec.DecodeLength()
+	o.Types = make([]TypeDef, n)
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		dec.Decode(&o.Types[i])
+	}
+
+	n = dec.DecodeLength()
+	o.Structs = make([]Struct, n)
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		dec.Decode(&o.Structs[i])
+	}
+
+	n = dec.DecodeLength()
+	o.Actions = make([]Action, n)
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		dec.Decode(&o.Actions[i])
+	}
+
+	n = dec.DecodeLength()
+	o.Tables = make([]Table, n)
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		dec.Decode(&o.Tables[i])
+	}
+
+	n = dec.DecodeLength()
+	o.ErrorMessages = make([]ErrorMessage, n)
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		dec.Decode(&o.ErrorMessages[i])
+	}
+
+	decodeRawExtensions(dec)
+	o.AbiExtensions = []interface{}{}
+	return dec.Err()
+}
+
+// normalize replaces nil slices with empty ones, the node refuses null arrays in abi_def
+func (o Abi) normalize() Abi {
+	if o.Types == nil {
+		o.Types = []TypeDef{}
+	}
+	if o.Structs == nil {
+		o.Structs = []Struct{}
+	}
+	for i := range o.Structs {
+		if o.Structs[i].Fields == nil {
+			o.Structs[i].Fields = []Field{}
+		}
+	}
+	if o.Actions == nil {
+		o.Actions = []Action{}
+	}
+	if o.Tables == nil {
+		o.Tables = []Table{}
+	}
+	for i := range o.Tables {
+		if o.Tables[i].KeyNames == nil {
+			o.Tables[i].KeyNames = []string{}
+		}
+		if o.Tables[i].KeyTypes == nil {
+			o.Tables[i].KeyTypes = []string{}
+		}
+	}
+	if o.ErrorMessages == nil {
+		o.ErrorMessages = []ErrorMessage{}
+	}
+	if o.AbiExtensions == nil {
+		o.AbiExtensions = []interface{}{}
+	}
+	return o
+}
+
 type ContractAccountProperties struct {
 	ID                            ObjectID `json:"id"`
 	MembershipExpirationDate      string   `json:"membership_expiration_date"`
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+	"gxclient-go/util"
+)
+
+// NewCallContractOperation returns a new instance of CallContractOperation
+func NewCallContractOperation(account, contractId ObjectID, methodName string, data Buffer, amount *AssetAmount, fee AssetAmount) *CallContractOperation {
+	op := &CallContractOperation{
+		Fee:        fee,
+		Account:    account,
+		ContractId: contractId,
+		Amount:     amount,
+		MethodName: methodName,
+		Data:       data,
+		Extensions: []json.RawMessage{},
+	}
+	return op
+}
+
+// CallContractOperation invokes an action of a contract
+type CallContractOperation struct {
+	Fee        AssetAmount       `json:"fee"`
+	Account    ObjectID          `json:"account"`
+	ContractId ObjectID          `json:"contract_id"`
+	Amount     *AssetAmount      `json:"amount,omitempty"`
+	MethodName string            `json:"method_name"`
+	Data       Buffer            `json:"data"`
+	Extensions []json.RawMessage `json:"extensions"`
+}
+
+func (op *CallContractOperation) Type() OpType { return CallContractOpType }
+
+func (op *CallContractOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Account)
+	enc.Encode(op.ContractId)
+
+	if op.Amount != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.Amount)
+	} else {
+		//Amount?
+		enc.EncodeUVarint(0)
+	}
+	enc.Encode(util.StringToName(op.MethodName))
+	enc.Encode(op.Data)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *CallContractOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Account = decodeAccountID(dec)
+	op.ContractId = decodeAccountID(dec)
+
+	//Amount?
+	if dec.DecodeUVarint() == 1 {
+		op.Amount = &AssetAmount{}
+		dec.Decode(op.Amount)
+	}
+
+	var name uint64
+	dec.Decode(&name)
+	op.MethodName = util.NameToString(name)
+	dec.Decode(&op.Data)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewCreateContractOperation returns a new instance of CreateContractOperation
+func NewCreateContractOperation(account ObjectID, name, vmType, vmVersion string, code Buffer, abi Abi, fee AssetAmount) *CreateContractOperation {
+	op := &CreateContractOperation{
+		Fee:        fee,
+		Name:       name,
+		Account:    account,
+		VmType:     vmType,
+		VmVersion:  vmVersion,
+		Code:       code,
+		Abi:        abi.normalize(),
+		Extensions: []json.RawMessage{},
+	}
+	return op
+}
+
+// CreateContractOperation deploys a new contract account
+type CreateContractOperation struct {
+	Fee        AssetAmount       `json:"fee"`
+	Name       string            `json:"name"`
+	Account    ObjectID          `json:"account"`
+	VmType     string            `json:"vm_type"`
+	VmVersion  string            `json:"vm_version"`
+	Code       Buffer            `json:"code"`
+	Abi        Abi               `json:"abi"`
+	Extensions []json.RawMessage `json:"extensions"`
+}
+
+func (op *CreateContractOperation) Type() OpType { return CreateContractOpType }
+
+func (op *CreateContractOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Name)
+	enc.Encode(op.Account)
+	enc.Encode(op.VmType)
+	enc.Encode(op.VmVersion)
+	enc.Encode(op.Code)
+	enc.Encode(op.Abi)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *CreateContractOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	dec.Decode(&op.Name)
+	op.Account = decodeAccountID(dec)
+	dec.Decode(&op.VmType)
+	dec.Decode(&op.VmVersion)
+	dec.Decode(&op.Code)
+	dec.Decode(&op.Abi)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
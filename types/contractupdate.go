@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewUpdateContractOperation returns a new instance of UpdateContractOperation
+func NewUpdateContractOperation(owner, contract ObjectID, newOwner *ObjectID, code Buffer, abi Abi, fee AssetAmount) *UpdateContractOperation {
+	op := &UpdateContractOperation{
+		Fee:        fee,
+		Owner:      owner,
+		NewOwner:   newOwner,
+		Contract:   contract,
+		Code:       code,
+		Abi:        abi.normalize(),
+		Extensions: []json.RawMessage{},
+	}
+	return op
+}
+
+// UpdateContractOperation replaces the code and abi of a contract, optionally transferring its ownership
+type UpdateContractOperation struct {
+	Fee        AssetAmount       `json:"fee"`
+	Owner      ObjectID          `json:"owner"`
+	NewOwner   *ObjectID         `json:"new_owner,omitempty"`
+	Contract   ObjectID          `json:"contract"`
+	Code       Buffer            `json:"code"`
+	Abi        Abi               `json:"abi"`
+	Extensions []json.RawMessage `json:"extensions"`
+}
+
+func (op *UpdateContractOperation) Type() OpType { return UpdateContractOpType }
+
+func (op *UpdateContractOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Owner)
+
+	if op.NewOwner != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.NewOwner)
+	} else {
+		//NewOwner?
+		enc.EncodeUVarint(0)
+	}
+	enc.Encode(op.Contract)
+	enc.Encode(op.Code)
+	enc.Encode(op.Abi)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *UpdateContractOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Owner = decodeAccountID(dec)
+
+	//NewOwner?
+	if dec.DecodeUVarint() == 1 {
+		newOwner := decodeAccountID(dec)
+		op.NewOwner = &newOwner
+	}
+	op.Contract = decodeAccountID(dec)
+	dec.Decode(&op.Code)
+	dec.Decode(&op.Abi)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
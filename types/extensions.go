@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"github.com/juju/errors"
 	"gxclient-go/transaction"
 )
@@ -20,3 +21,30 @@ func (p Extensions) MarshalTransaction(enc *transaction.Encoder) error {
 
 	return nil
 }
+
+// UnmarshalTransaction only accepts empty extensions, their content is not typed
+func (p *Extensions) UnmarshalTransaction(dec *transaction.Decoder) error {
+	return decodeEmptyExtensions(dec)
+}
+
+// decodeEmptyExtensions reads the extensions length and fails unless it is zero
+func decodeEmptyExtensions(dec *transaction.Decoder) error {
+	n, err := dec.DecodeUVarint()
+	if err != nil {
+		return errors.Annotate(err, "decode length")
+	}
+
+	if n != 0 {
+		return errors.Errorf("%d extensions found, decoding extensions is not supported", n)
+	}
+
+	return nil
+}
+
+// decodeRawExtensions reads the empty extensions of an operation
+func decodeRawExtensions(dec *transaction.RollingDecoder) []json.RawMessage {
+	if n := dec.DecodeUVarint(); n != 0 {
+		dec.SetErr(errors.Errorf("%d extensions found, decoding extensions is not supported", n))
+	}
+	return []json.RawMessage{}
+}
@@ -66,6 +66,18 @@ func (p GrapheneID) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+// UnmarshalTransaction reads the instance, space and type are not serialized so those already set are kept
+func (p *GrapheneID) UnmarshalTransaction(dec *transaction.Decoder) error {
+	instance, err := dec.DecodeUVarint()
+	if err != nil {
+		return errors.Annotate(err, "decode instance")
+	}
+
+	p.instance = UInt64(instance)
+	p.resetID()
+	return nil
+}
+
 func (p GrapheneID) MarshalJSON() ([]byte, error) {
 	return ffjson.Marshal(p.id)
 }
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewInlineTransferOperation returns a new instance of InlineTransferOperation,
+// such transfers are emitted by contracts
+func NewInlineTransferOperation(from, to ObjectID, amount AssetAmount, memo string, fee AssetAmount) *InlineTransferOperation {
+	op := &InlineTransferOperation{
+		Fee:        fee,
+		From:       from,
+		To:         to,
+		Amount:     amount,
+		Memo:       memo,
+		Extensions: []json.RawMessage{},
+	}
+	return op
+}
+
+// InlineTransferOperation
+type InlineTransferOperation struct {
+	Fee        AssetAmount       `json:"fee"`
+	From       ObjectID          `json:"from"`
+	To         ObjectID          `json:"to"`
+	Amount     AssetAmount       `json:"amount"`
+	Memo       string            `json:"memo"`
+	Extensions []json.RawMessage `json:"extensions"`
+}
+
+func (op *InlineTransferOperation) Type() OpType { return InlineTransferOpType }
+
+func (op *InlineTransferOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.Amount)
+	enc.Encode(op.Memo)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *InlineTransferOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.From = decodeAccountID(dec)
+	op.To = decodeAccountID(dec)
+	dec.Decode(&op.Amount)
+	dec.Decode(&op.Memo)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
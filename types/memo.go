@@ -42,6 +42,26 @@ func (p Memo) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *Memo) UnmarshalTransaction(dec *transaction.Decoder) error {
+	if err := dec.Decode(&p.From); err != nil {
+		return errors.Annotate(err, "decode from")
+	}
+
+	if err := dec.Decode(&p.To); err != nil {
+		return errors.Annotate(err, "decode to")
+	}
+
+	if err := dec.Decode(&p.Nonce); err != nil {
+		return errors.Annotate(err, "decode nonce")
+	}
+
+	if err := dec.Decode(&p.Message); err != nil {
+		return errors.Annotate(err, "decode Message")
+	}
+
+	return nil
+}
+
 func (m *Memo) UnmarshalJSON(b []byte) (err error) {
 	stringCase := struct {
 		From    PublicKey `json:"from"`
@@ -132,6 +152,10 @@ func (p Memo) Decrypt(priv *PrivateKey) (string, error) {
 		return "", errors.Annotate(err, "cypherBlock")
 	}
 
+	if len(p.Message) == 0 || len(p.Message)%aes.BlockSize != 0 {
+		return "", ErrInvalidMemo
+	}
+
 	mode := cipher.NewCBCDecrypter(blk, iv)
 	dst := make([]byte, len(p.Message))
 	mode.CryptBlocks(dst, p.Message)
@@ -166,8 +190,15 @@ func (p Memo) cypherBlock(sec []byte) ([]byte, cipher.Block, error) {
 }
 
 func unpad(buf []byte) []byte {
+	if len(buf) == 0 {
+		return buf
+	}
+
 	b := buf[len(buf)-1:][0]
 	cnt := int(b)
+	if cnt == 0 || cnt > len(buf) {
+		return buf
+	}
 	l := len(buf) - cnt
 
 	a := bytes.Repeat([]byte{b}, cnt)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"gxclient-go/transaction"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -45,6 +46,28 @@ func (o ObjectID) MarshalTransaction(encoder *transaction.Encoder) error {
 	return nil
 }
 
+// UnmarshalTransaction reads the instance, space and type are not serialized so those already set are kept
+func (o *ObjectID) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	id, err := decoder.DecodeUVarint()
+	if err != nil {
+		return err
+	}
+	o.ID = id
+	return nil
+}
+
+// decodeObjectID reads the instance of an object id of the given space and type
+func decodeObjectID(dec *transaction.RollingDecoder, space SpaceType, typ ObjectType) ObjectID {
+	id := ObjectID{Space: uint64(space), Type: uint64(typ)}
+	dec.Decode(&id)
+	return id
+}
+
+// decodeAccountID reads the instance of an account id 1.2.x
+func decodeAccountID(dec *transaction.RollingDecoder) ObjectID {
+	return decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeAccount)
+}
+
 func MustParseObjectID(str string) ObjectID {
 	out, err := ParseObjectID(str)
 	if err != nil {
@@ -92,3 +115,29 @@ func unquote(in string) (string, error) {
 	}
 	return in, nil
 }
+
+// ObjectIDs is a flat_set of object ids, serialized sorted by instance
+type ObjectIDs []ObjectID
+
+func (p ObjectIDs) MarshalTransaction(encoder *transaction.Encoder) error {
+	ids := make([]ObjectID, len(p))
+	copy(ids, p)
+	sort.Slice(ids, func(i, j int) bool { return ids[i].ID < ids[j].ID })
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(len(ids)))
+	for _, id := range ids {
+		enc.Encode(id)
+	}
+	return enc.Err()
+}
+
+// decodeObjectIDs reads a flat_set of object ids of the given space and type
+func decodeObjectIDs(dec *transaction.RollingDecoder, space SpaceType, typ ObjectType) ObjectIDs {
+	n := dec.DecodeLength()
+	ids := ObjectIDs{}
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		ids = append(ids, decodeObjectID(dec, space, typ))
+	}
+	return ids
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+type OperationResultType uint8
+
+const (
+	VoidResultType OperationResultType = iota
+	ObjectIDResultType
+	AssetResultType
+)
+
+// OperationResult is the result of an applied operation, [resultType, value] in JSON.
+// ObjectID is set for object id results, Asset for asset results,
+// Raw holds the value of other result types such as contract receipts
+type OperationResult struct {
+	Type     OperationResultType
+	ObjectID *ObjectID
+	Asset    *AssetAmount
+	Raw      json.RawMessage
+}
+
+func (r *OperationResult) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal operation result: %v", string(data))
+	}
+	if len(raw) != 2 {
+		return errors.Errorf("invalid operation result: %v", string(data))
+	}
+
+	var resultType OperationResultType
+	if err := json.Unmarshal(raw[0], &resultType); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal operation result type: %v", string(raw[0]))
+	}
+
+	result := OperationResult{Type: resultType, Raw: raw[1]}
+	switch resultType {
+	case ObjectIDResultType:
+		result.ObjectID = &ObjectID{}
+		if err := json.Unmarshal(raw[1], result.ObjectID); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal object id result: %v", string(raw[1]))
+		}
+	case AssetResultType:
+		result.Asset = &AssetAmount{}
+		if err := json.Unmarshal(raw[1], result.Asset); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal asset result: %v", string(raw[1]))
+		}
+	}
+
+	*r = result
+	return nil
+}
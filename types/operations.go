@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"github.com/pkg/errors"
+	"gxclient-go/transaction"
 	"reflect"
 )
 
@@ -50,12 +51,102 @@ func (ops *Operations) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (ops *Operations) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	n, err := decoder.DecodeLength()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode operations length")
+	}
+
+	items := make([]Operation, 0, n)
+	for i := 0; i < n; i++ {
+		op, err := decodeOperation(decoder)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode operation %d", i)
+		}
+		items = append(items, op)
+	}
+
+	*ops = items
+	return nil
+}
+
+// decodeOperation reads the operation type and then the body of the registered operation type,
+// the body is decoded by the UnmarshalTransaction of the operation
+func decodeOperation(decoder *transaction.Decoder) (Operation, error) {
+	opType, err := decoder.DecodeUVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode operation type")
+	}
+
+	template, ok := dataObjects[OpType(opType)]
+	if !ok {
+		return nil, errors.Errorf("unknown operation type %d", opType)
+	}
+
+	op := reflect.New(reflect.Indirect(reflect.ValueOf(template)).Type()).Interface().(Operation)
+	unmarshaller, ok := op.(transaction.TransactionUnmarshaller)
+	if !ok {
+		return nil, errors.Errorf("decoding operation type %d is not supported", opType)
+	}
+	if err := unmarshaller.UnmarshalTransaction(decoder); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode operation type %d", opType)
+	}
+	return op, nil
+}
+
+// SetOperationFee sets the fee of op, a pointer to an operation with a Fee field
+func SetOperationFee(op Operation, fee AssetAmount) error {
+	v := reflect.ValueOf(op)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("operation type %d is not a pointer to a struct", op.Type())
+	}
+
+	field := v.Elem().FieldByName("Fee")
+	switch {
+	case !field.IsValid():
+		return errors.Errorf("operation type %d has no fee", op.Type())
+	case field.Type() == reflect.TypeOf(fee):
+		field.Set(reflect.ValueOf(fee))
+	case field.Type() == reflect.TypeOf(&fee):
+		field.Set(reflect.ValueOf(&fee))
+	default:
+		return errors.Errorf("operation type %d has no fee", op.Type())
+	}
+	return nil
+}
+
 var dataObjects = map[OpType]Operation{
 	TransferOpType:      &TransferOperation{},
 	StakingCreateOpType: &StakingCreateOperation{},
 	StakingUpdateOpType: &StakingUpdateOperation{},
 	StakingClaimOpType:  &StakingClaimOperation{},
 	AccountCreateOpType: &AccountCreateOperation{},
+
+	CreateContractOpType: &CreateContractOperation{},
+	CallContractOpType:   &CallContractOperation{},
+	UpdateContractOpType: &UpdateContractOperation{},
+
+	AccountUpdateOpType:           &AccountUpdateOperation{},
+	AccountUpgradeOpType:          &AccountUpgradeOperation{},
+	AssetCreateOpType:             &AssetCreateOperation{},
+	AssetUpdateOpType:             &AssetUpdateOperation{},
+	AssetIssueOpType:              &AssetIssueOperation{},
+	AssetReserveOpType:            &AssetReserveOperation{},
+	AssetFundFeePoolOpType:        &AssetFundFeePoolOperation{},
+	ProposalCreateOpType:          &ProposalCreateOperation{},
+	ProposalUpdateOpType:          &ProposalUpdateOperation{},
+	ProposalDeleteOpType:          &ProposalDeleteOperation{},
+	VestingBalanceCreateOpType:    &VestingBalanceCreateOperation{},
+	VestingBalanceWithdrawOpType:  &VestingBalanceWithdrawOperation{},
+	BalanceLockOpType:             &BalanceLockOperation{},
+	BalanceUnlockOpType:           &BalanceUnlockOperation{},
+	ProxyTransferOpType:           &ProxyTransferOperation{},
+	InlineTransferOpType:          &InlineTransferOperation{},
+	WitnessCreateOpType:           &WitnessCreateOperation{},
+	WitnessUpdateOpType:           &WitnessUpdateOperation{},
+	CommitteeMemberCreateOpType:   &CommitteeMemberCreateOperation{},
+	CommitteeMemberUpdateOpType:   &CommitteeMemberUpdateOperation{},
+	TrustNodePledgeWithdrawOpType: &TrustNodePledgeWithdrawOperation{},
 }
 
 func (op *operationTuple) UnmarshalJSON(data []byte) error {
@@ -95,6 +186,16 @@ func (op *operationTuple) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnmarshalOperation decodes an operation object [opType, opBody] into the registered operation type,
+// unregistered types are returned as *UnknownOperation
+func UnmarshalOperation(data []byte) (Operation, error) {
+	var tuple operationTuple
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return nil, err
+	}
+	return tuple.Data, nil
+}
+
 type UnknownOperation struct {
 	kind OpType
 	data *json.RawMessage
@@ -8,7 +8,7 @@ const (
 	LimitOrderCancelOpType
 	CallOrderUpdateOpType
 	FillOrderOpType
-	AccountCreateOpType = 5
+	AccountCreateOpType
 	AccountUpdateOpType
 	AccountWhitelistOpType
 	AccountUpgradeOpType
@@ -76,14 +76,14 @@ const (
 	DataTransactionComplainOpType
 	BalanceLockOpType
 	BalanceUnlockOpType
-	ProxyTransferOpType  = 73
-	CreateContractOpType = 74
-	CallContractOpType   = 75
-	UpdateContractOpType = 76
+	ProxyTransferOpType
+	CreateContractOpType
+	CallContractOpType
+	UpdateContractOpType
 	TrustNodePledgeWithdrawOpType
 	InlineTransferOpType
 	InterContractCallOpType
-	StakingCreateOpType = 80
-	StakingUpdateOpType = 81
-	StakingClaimOpType  = 82
+	StakingCreateOpType
+	StakingUpdateOpType
+	StakingClaimOpType
 )
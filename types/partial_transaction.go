@@ -0,0 +1,71 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// PartiallySignedTransaction is the portable form of a transaction being co-signed by several parties,
+// it carries the chain id so that every party signs the same digest
+type PartiallySignedTransaction struct {
+	ChainID     string       `json:"chain_id"`
+	Transaction *Transaction `json:"transaction"`
+}
+
+func NewPartiallySignedTransaction(tx *SignedTransaction, chainID string) *PartiallySignedTransaction {
+	return &PartiallySignedTransaction{
+		ChainID:     chainID,
+		Transaction: tx.Transaction,
+	}
+}
+
+// ParsePartiallySignedTransaction reads the json written by json.Marshal of a PartiallySignedTransaction
+func ParsePartiallySignedTransaction(data []byte) (*PartiallySignedTransaction, error) {
+	var p PartiallySignedTransaction
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal partially signed transaction")
+	}
+
+	if chainID, err := hex.DecodeString(p.ChainID); err != nil || len(chainID) != 32 {
+		return nil, errors.Errorf("invalid chain ID: %v", p.ChainID)
+	}
+
+	if p.Transaction == nil || p.Transaction.Expiration.Time == nil {
+		return nil, errors.New("missing transaction")
+	}
+
+	// the transaction must serialize to be signed
+	if _, err := p.SignedTransaction().Serialize(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// MarshalJSON keeps the signatures an array when there are none yet
+func (p PartiallySignedTransaction) MarshalJSON() ([]byte, error) {
+	tx := *p.Transaction
+	if tx.Signatures == nil {
+		tx.Signatures = []string{}
+	}
+
+	type partial PartiallySignedTransaction
+	return json.Marshal(partial{ChainID: p.ChainID, Transaction: &tx})
+}
+
+func (p *PartiallySignedTransaction) SignedTransaction() *SignedTransaction {
+	return NewSignedTransaction(p.Transaction)
+}
+
+// Sign adds the signature of wif
+func (p *PartiallySignedTransaction) Sign(wif string) error {
+	return p.SignedTransaction().AppendSignature(wif, p.ChainID)
+}
+
+// Merge adds the signatures of other that are missing, other must be the same transaction on the same chain
+func (p *PartiallySignedTransaction) Merge(other *PartiallySignedTransaction) error {
+	if p.ChainID != other.ChainID {
+		return errors.Errorf("failed to merge signatures for chain %s into chain %s", other.ChainID, p.ChainID)
+	}
+	return p.SignedTransaction().MergeSignatures(other.SignedTransaction())
+}
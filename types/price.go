@@ -11,6 +11,20 @@ type Price struct {
 	Quote AssetAmount `json:"quote"`
 }
 
+func (p Price) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(p.Base)
+	enc.Encode(p.Quote)
+	return enc.Err()
+}
+
+func (p *Price) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&p.Base)
+	dec.Decode(&p.Quote)
+	return dec.Err()
+}
+
 type AssetAmount struct {
 	Amount  uint64   `json:"amount"`
 	AssetID ObjectID `json:"asset_id"`
@@ -23,6 +37,13 @@ func (aa AssetAmount) MarshalTransaction(encoder *transaction.Encoder) error {
 	return enc.Err()
 }
 
+func (aa *AssetAmount) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	aa.Amount = dec.DecodeLittleEndianUInt64()
+	aa.AssetID = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeAsset)
+	return dec.Err()
+}
+
 // RPC client might return asset amount as uint64 or string,
 // therefore a custom unmarshaller is used
 func (aa *AssetAmount) UnmarshalJSON(b []byte) (err error) {
@@ -0,0 +1,16 @@
+package types
+
+// Proposal is a proposal_object, the operations of ProposedTransaction are decoded
+type Proposal struct {
+	ID                       ObjectID     `json:"id"`
+	Proposer                 *ObjectID    `json:"proposer,omitempty"`
+	ExpirationTime           Time         `json:"expiration_time"`
+	ReviewPeriodTime         *Time        `json:"review_period_time,omitempty"`
+	ProposedTransaction      *Transaction `json:"proposed_transaction"`
+	RequiredActiveApprovals  ObjectIDs    `json:"required_active_approvals"`
+	AvailableActiveApprovals ObjectIDs    `json:"available_active_approvals"`
+	RequiredOwnerApprovals   ObjectIDs    `json:"required_owner_approvals"`
+	AvailableOwnerApprovals  ObjectIDs    `json:"available_owner_approvals"`
+	AvailableKeyApprovals    PublicKeys   `json:"available_key_approvals"`
+	FailReason               string       `json:"fail_reason,omitempty"`
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gxclient-go/transaction"
+)
+
+// OpWrapper wraps a proposed operation, {"op": [opType, opBody]} in JSON
+type OpWrapper struct {
+	Op Operation
+}
+
+func (w OpWrapper) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"op": &operationTuple{Type: w.Op.Type(), Data: w.Op},
+	})
+}
+
+func (w *OpWrapper) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Op json.RawMessage `json:"op"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal op wrapper: %v", string(data))
+	}
+	op, err := UnmarshalOperation(raw.Op)
+	if err != nil {
+		return err
+	}
+	w.Op = op
+	return nil
+}
+
+func (w OpWrapper) MarshalTransaction(encoder *transaction.Encoder) error {
+	return encoder.Encode(w.Op)
+}
+
+func (w *OpWrapper) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	op, err := decodeOperation(decoder)
+	if err != nil {
+		return err
+	}
+	w.Op = op
+	return nil
+}
+
+// NewProposalCreateOperation returns a new instance of ProposalCreateOperation,
+// a nil reviewPeriodSeconds proposes without review period
+func NewProposalCreateOperation(feePayingAccount ObjectID, ops []Operation, expirationTime Time, reviewPeriodSeconds *uint32, fee AssetAmount) *ProposalCreateOperation {
+	wrappers := make([]OpWrapper, 0, len(ops))
+	for _, op := range ops {
+		wrappers = append(wrappers, OpWrapper{Op: op})
+	}
+
+	op := &ProposalCreateOperation{
+		Fee:                 fee,
+		FeePayingAccount:    feePayingAccount,
+		ProposedOps:         wrappers,
+		ExpirationTime:      expirationTime,
+		ReviewPeriodSeconds: reviewPeriodSeconds,
+		Extensions:          []json.RawMessage{},
+	}
+	return op
+}
+
+// ProposalCreateOperation
+type ProposalCreateOperation struct {
+	Fee                 AssetAmount       `json:"fee"`
+	FeePayingAccount    ObjectID          `json:"fee_paying_account"`
+	ProposedOps         []OpWrapper       `json:"proposed_ops"`
+	ExpirationTime      Time              `json:"expiration_time"`
+	ReviewPeriodSeconds *uint32           `json:"review_period_seconds,omitempty"`
+	Extensions          []json.RawMessage `json:"extensions"`
+}
+
+func (op *ProposalCreateOperation) Type() OpType { return ProposalCreateOpType }
+
+func (op *ProposalCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.FeePayingAccount)
+
+	enc.EncodeUVarint(uint64(len(op.ProposedOps)))
+	for _, wrapper := range op.ProposedOps {
+		enc.Encode(wrapper)
+	}
+
+	enc.Encode(op.ExpirationTime)
+
+	//ReviewPeriodSeconds?
+	if op.ReviewPeriodSeconds != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(*op.ReviewPeriodSeconds)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *ProposalCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.FeePayingAccount = decodeAccountID(dec)
+
+	n := dec.DecodeLength()
+	op.ProposedOps = []OpWrapper{}
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		var wrapper OpWrapper
+		dec.Decode(&wrapper)
+		op.ProposedOps = append(op.ProposedOps, wrapper)
+	}
+
+	dec.Decode(&op.ExpirationTime)
+
+	//ReviewPeriodSeconds?
+	if dec.DecodeUVarint() == 1 {
+		op.ReviewPeriodSeconds = new(uint32)
+		dec.Decode(op.ReviewPeriodSeconds)
+	}
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
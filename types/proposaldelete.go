@@ -0,0 +1,52 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewProposalDeleteOperation returns a new instance of ProposalDeleteOperation
+func NewProposalDeleteOperation(feePayingAccount, proposal ObjectID, usingOwnerAuthority bool, fee AssetAmount) *ProposalDeleteOperation {
+	op := &ProposalDeleteOperation{
+		Fee:                 fee,
+		FeePayingAccount:    feePayingAccount,
+		UsingOwnerAuthority: usingOwnerAuthority,
+		Proposal:            proposal,
+		Extensions:          []json.RawMessage{},
+	}
+	return op
+}
+
+// ProposalDeleteOperation
+type ProposalDeleteOperation struct {
+	Fee                 AssetAmount       `json:"fee"`
+	FeePayingAccount    ObjectID          `json:"fee_paying_account"`
+	UsingOwnerAuthority bool              `json:"using_owner_authority"`
+	Proposal            ObjectID          `json:"proposal"`
+	Extensions          []json.RawMessage `json:"extensions"`
+}
+
+func (op *ProposalDeleteOperation) Type() OpType { return ProposalDeleteOpType }
+
+func (op *ProposalDeleteOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.FeePayingAccount)
+	enc.EncodeBool(op.UsingOwnerAuthority)
+	enc.Encode(op.Proposal)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *ProposalDeleteOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.FeePayingAccount = decodeAccountID(dec)
+	op.UsingOwnerAuthority = dec.DecodeBool()
+	op.Proposal = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeProposal)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
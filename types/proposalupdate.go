@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/json"
+	"gxclient-go/transaction"
+)
+
+// NewProposalUpdateOperation returns a new instance of ProposalUpdateOperation without any approval change,
+// approvals are set on the returned operation
+func NewProposalUpdateOperation(feePayingAccount, proposal ObjectID, fee AssetAmount) *ProposalUpdateOperation {
+	op := &ProposalUpdateOperation{
+		Fee:                     fee,
+		FeePayingAccount:        feePayingAccount,
+		Proposal:                proposal,
+		ActiveApprovalsToAdd:    ObjectIDs{},
+		ActiveApprovalsToRemove: ObjectIDs{},
+		OwnerApprovalsToAdd:     ObjectIDs{},
+		OwnerApprovalsToRemove:  ObjectIDs{},
+		KeyApprovalsToAdd:       PublicKeys{},
+		KeyApprovalsToRemove:    PublicKeys{},
+		Extensions:              []json.RawMessage{},
+	}
+	return op
+}
+
+// ProposalUpdateOperation
+type ProposalUpdateOperation struct {
+	Fee                     AssetAmount       `json:"fee"`
+	FeePayingAccount        ObjectID          `json:"fee_paying_account"`
+	Proposal                ObjectID          `json:"proposal"`
+	ActiveApprovalsToAdd    ObjectIDs         `json:"active_approvals_to_add"`
+	ActiveApprovalsToRemove ObjectIDs         `json:"active_approvals_to_remove"`
+	OwnerApprovalsToAdd     ObjectIDs         `json:"owner_approvals_to_add"`
+	OwnerApprovalsToRemove  ObjectIDs         `json:"owner_approvals_to_remove"`
+	KeyApprovalsToAdd       PublicKeys        `json:"key_approvals_to_add"`
+	KeyApprovalsToRemove    PublicKeys        `json:"key_approvals_to_remove"`
+	Extensions              []json.RawMessage `json:"extensions"`
+}
+
+func (op *ProposalUpdateOperation) Type() OpType { return ProposalUpdateOpType }
+
+func (op *ProposalUpdateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.FeePayingAccount)
+	enc.Encode(op.Proposal)
+	enc.Encode(op.ActiveApprovalsToAdd)
+	enc.Encode(op.ActiveApprovalsToRemove)
+	enc.Encode(op.OwnerApprovalsToAdd)
+	enc.Encode(op.OwnerApprovalsToRemove)
+	enc.Encode(op.KeyApprovalsToAdd)
+	enc.Encode(op.KeyApprovalsToRemove)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *ProposalUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.FeePayingAccount = decodeAccountID(dec)
+	op.Proposal = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeProposal)
+	op.ActiveApprovalsToAdd = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAccount)
+	op.ActiveApprovalsToRemove = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAccount)
+	op.OwnerApprovalsToAdd = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAccount)
+	op.OwnerApprovalsToRemove = decodeObjectIDs(dec, SpaceTypeProtocol, ObjectTypeAccount)
+	dec.Decode(&op.KeyApprovalsToAdd)
+	dec.Decode(&op.KeyApprovalsToRemove)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
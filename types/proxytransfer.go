@@ -0,0 +1,100 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gxclient-go/transaction"
+)
+
+// ProxyTransferParams is the transfer request signed by its sender, Percentage is the
+// part of the amount kept by the proxy in 1/10000
+type ProxyTransferParams struct {
+	From         ObjectID    `json:"from"`
+	To           ObjectID    `json:"to"`
+	ProxyAccount ObjectID    `json:"proxy_account"`
+	Amount       AssetAmount `json:"amount"`
+	Percentage   uint16      `json:"percentage"`
+	Memo         string      `json:"memo"`
+	Expiration   Time        `json:"expiration"`
+	Signatures   []Buffer    `json:"signatures"`
+}
+
+func (p ProxyTransferParams) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(p.From)
+	enc.Encode(p.To)
+	enc.Encode(p.ProxyAccount)
+	enc.Encode(p.Amount)
+	enc.Encode(p.Percentage)
+	enc.Encode(p.Memo)
+	enc.Encode(p.Expiration)
+
+	// signatures are fixed size, without length prefix
+	enc.EncodeUVarint(uint64(len(p.Signatures)))
+	for _, sig := range p.Signatures {
+		if len(sig) != 65 {
+			return errors.Errorf("invalid signature length %d", len(sig))
+		}
+		enc.Encode(sig.Bytes())
+	}
+	return enc.Err()
+}
+
+func (p *ProxyTransferParams) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	p.From = decodeAccountID(dec)
+	p.To = decodeAccountID(dec)
+	p.ProxyAccount = decodeAccountID(dec)
+	dec.Decode(&p.Amount)
+	dec.Decode(&p.Percentage)
+	dec.Decode(&p.Memo)
+	dec.Decode(&p.Expiration)
+
+	n := dec.DecodeLength()
+	p.Signatures = []Buffer{}
+	for i := 0; i < n && dec.Err() == nil; i++ {
+		p.Signatures = append(p.Signatures, dec.DecodeBytes(65))
+	}
+	return dec.Err()
+}
+
+// NewProxyTransferOperation returns a new instance of ProxyTransferOperation
+func NewProxyTransferOperation(params ProxyTransferParams, fee AssetAmount) *ProxyTransferOperation {
+	if params.Signatures == nil {
+		params.Signatures = []Buffer{}
+	}
+	op := &ProxyTransferOperation{
+		Fee:           fee,
+		RequestParams: params,
+		Extensions:    []json.RawMessage{},
+	}
+	return op
+}
+
+// ProxyTransferOperation
+type ProxyTransferOperation struct {
+	Fee           AssetAmount         `json:"fee"`
+	RequestParams ProxyTransferParams `json:"request_params"`
+	Extensions    []json.RawMessage   `json:"extensions"`
+}
+
+func (op *ProxyTransferOperation) Type() OpType { return ProxyTransferOpType }
+
+func (op *ProxyTransferOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.RequestParams)
+
+	//Extensions
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *ProxyTransferOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	dec.Decode(&op.RequestParams)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
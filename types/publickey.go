@@ -58,6 +58,21 @@ func (p PublicKey) MarshalTransaction(enc *transaction.Encoder) error {
 	return enc.Encode(p.Bytes())
 }
 
+func (p *PublicKey) UnmarshalTransaction(dec *transaction.Decoder) error {
+	b, err := dec.DecodeBytes(33)
+	if err != nil {
+		return errors.Annotate(err, "decode key")
+	}
+
+	pub, err := NewPublicKeyFromBytes(b)
+	if err != nil {
+		return errors.Annotate(err, "NewPublicKeyFromBytes")
+	}
+
+	*p = *pub
+	return nil
+}
+
 func (p *PublicKey) ToAddress() (*Address, error) {
 	return NewAddress(p)
 }
@@ -84,8 +99,8 @@ func (p PublicKey) IsNul() bool {
 	return p.key == nil
 }
 
-//NewPublicKey creates a new PublicKey from string
-//e.g.("GXC6K35Bajw29N4fjP4XADHtJ7bEj2xHJ8CoY2P2s1igXTB5oMBhR")
+// NewPublicKey creates a new PublicKey from string
+// e.g.("GXC6K35Bajw29N4fjP4XADHtJ7bEj2xHJ8CoY2P2s1igXTB5oMBhR")
 func NewPublicKeyFromString(key string) (*PublicKey, error) {
 	prefixChain := "GXC"
 
@@ -142,6 +157,16 @@ func NewPublicKey(pub *btcec.PublicKey) (*PublicKey, error) {
 	return &k, nil
 }
 
+// NewPublicKeyFromBytes creates a new PublicKey from its compressed serialization
+func NewPublicKeyFromBytes(b []byte) (*PublicKey, error) {
+	pub, err := btcec.ParsePubKey(b, btcec.S256())
+	if err != nil {
+		return nil, errors.Annotate(err, "ParsePubKey")
+	}
+
+	return NewPublicKey(pub)
+}
+
 func PublicKeyComparator(key1, key2 *PublicKey) (int, error) {
 	addr1, err := key1.ToAddress()
 	if err != nil {
@@ -155,3 +180,50 @@ func PublicKeyComparator(key1, key2 *PublicKey) (int, error) {
 
 	return sort.StringComparator(addr1.String(), addr2.String()), nil
 }
+
+// MarshalTransaction serializes the keys as a flat_set, sorted like key auths
+func (p PublicKeys) MarshalTransaction(enc *transaction.Encoder) error {
+	if err := enc.EncodeUVarint(uint64(len(p))); err != nil {
+		return errors.Annotate(err, "encode length")
+	}
+
+	keys := make([]interface{}, 0, len(p))
+	for i := range p {
+		keys = append(keys, &p[i])
+	}
+
+	var err error
+	sort.Sort(keys, func(a, b interface{}) (s int) {
+		s, err = PublicKeyComparator(a.(*PublicKey), b.(*PublicKey))
+		return
+	})
+	if err != nil {
+		return errors.Annotate(err, "Sort")
+	}
+
+	for _, k := range keys {
+		if err := enc.Encode(k.(*PublicKey)); err != nil {
+			return errors.Annotate(err, "encode Key")
+		}
+	}
+
+	return nil
+}
+
+func (p *PublicKeys) UnmarshalTransaction(dec *transaction.Decoder) error {
+	n, err := dec.DecodeLength()
+	if err != nil {
+		return errors.Annotate(err, "decode length")
+	}
+
+	*p = PublicKeys{}
+	for i := 0; i < n; i++ {
+		var pub PublicKey
+		if err := dec.Decode(&pub); err != nil {
+			return errors.Annotate(err, "decode Key")
+		}
+		*p = append(*p, pub)
+	}
+
+	return nil
+}
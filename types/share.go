@@ -0,0 +1,29 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Share is an amount of an asset in its smallest unit, the node sends the large ones as strings
+type Share int64
+
+func (s *Share) UnmarshalJSON(b []byte) error {
+	var i int64
+	if err := json.Unmarshal(b, &i); err == nil {
+		*s = Share(i)
+		return nil
+	}
+
+	// failed on int64, try string
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	i, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return err
+	}
+	*s = Share(i)
+	return nil
+}
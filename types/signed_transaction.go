@@ -87,3 +87,99 @@ func (tx *SignedTransaction) Sign(wifs []string, chain string) error {
 	tx.Transaction.Signatures = sigsHex
 	return nil
 }
+
+// SignWith signs the transaction with signers, the signatures replace those already present
+func (tx *SignedTransaction) SignWith(chain string, signers ...Signer) error {
+	digest, err := tx.Digest(chain)
+	if err != nil {
+		return err
+	}
+
+	sigsHex := make([]string, len(signers))
+	for index, signer := range signers {
+		sig, _, err := signer.Sign(digest)
+		if err != nil {
+			return errors.Wrap(err, "failed to sign the transaction")
+		}
+		sigsHex[index] = hex.EncodeToString(sig)
+	}
+	tx.Transaction.Signatures = sigsHex
+	return nil
+}
+
+// AppendSignature signs the transaction with wif and adds the signature to those already present,
+// a signature already present is not added twice
+func (tx *SignedTransaction) AppendSignature(wif, chain string) error {
+	digest, err := tx.Digest(chain)
+	if err != nil {
+		return err
+	}
+
+	w, err := btcutil.DecodeWIF(wif)
+	if err != nil {
+		return err
+	}
+
+	sig := hex.EncodeToString(sign.SignBufferSha256(digest, w.PrivKey.ToECDSA()))
+	tx.addSignature(sig)
+	return nil
+}
+
+// MergeSignatures adds the signatures of other that are missing, other must be the same transaction
+func (tx *SignedTransaction) MergeSignatures(other *SignedTransaction) error {
+	raw, err := tx.Serialize()
+	if err != nil {
+		return err
+	}
+
+	otherRaw, err := other.Serialize()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(raw, otherRaw) {
+		return errors.New("failed to merge signatures of a different transaction")
+	}
+
+	for _, sig := range other.Signatures {
+		tx.addSignature(sig)
+	}
+	return nil
+}
+
+func (tx *SignedTransaction) addSignature(sig string) {
+	for _, s := range tx.Signatures {
+		if s == sig {
+			return
+		}
+	}
+	tx.Signatures = append(tx.Signatures, sig)
+}
+
+// SignerKeys recovers the public keys of the signatures
+func (tx *SignedTransaction) SignerKeys(chain string) ([]*PublicKey, error) {
+	digest, err := tx.Digest(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*PublicKey, 0, len(tx.Signatures))
+	for _, sigHex := range tx.Signatures {
+		sig, err := hex.DecodeString(sigHex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode signature: %v", sigHex)
+		}
+
+		pub, err := sign.RecoverPublicKey(digest, sig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to recover public key of signature: %v", sigHex)
+		}
+
+		key, err := NewPublicKey(pub)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"github.com/pkg/errors"
+	"gxclient-go/sign"
+)
+
+// Signer signs digests with a private key it holds, the key may live outside of the process, e.g. in a HSM
+// or a signing daemon
+type Signer interface {
+	// PublicKey returns the public key of the signing key
+	PublicKey() *PublicKey
+	// Sign returns the 65 bytes compact signature of the sha256 digest and the public key it recovers to
+	Sign(digest []byte) ([]byte, *PublicKey, error)
+}
+
+// KeySigner is a Signer with the private key in memory
+type KeySigner struct {
+	key *PrivateKey
+}
+
+// NewKeySigner returns a Signer signing with key
+func NewKeySigner(key *PrivateKey) *KeySigner {
+	return &KeySigner{key: key}
+}
+
+// NewKeySignerFromWif returns a Signer signing with the private key wif
+func NewKeySignerFromWif(wif string) (*KeySigner, error) {
+	key, err := NewPrivateKeyFromWif(wif)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key), nil
+}
+
+func (s *KeySigner) PublicKey() *PublicKey {
+	return s.key.PublicKey()
+}
+
+func (s *KeySigner) Sign(digest []byte) ([]byte, *PublicKey, error) {
+	sig := sign.SignBufferSha256(digest, s.key.ToECDSA())
+	if sig == nil {
+		return nil, nil, errors.New("failed to sign digest")
+	}
+	return sig, s.key.PublicKey(), nil
+}
@@ -37,3 +37,12 @@ func (op *StakingClaimOperation) MarshalTransaction(encoder *transaction.Encoder
 	enc.EncodeUVarint(0)
 	return enc.Err()
 }
+
+func (op *StakingClaimOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Owner = decodeAccountID(dec)
+	op.StakingId = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeStaking)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
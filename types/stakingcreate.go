@@ -49,3 +49,16 @@ func (op *StakingCreateOperation) MarshalTransaction(encoder *transaction.Encode
 	enc.EncodeUVarint(0)
 	return enc.Err()
 }
+
+func (op *StakingCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Owner = decodeAccountID(dec)
+	op.TrustNode = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeWitness)
+	dec.Decode(&op.Amount)
+	dec.Decode(&op.ProgramId)
+	dec.Decode(&op.Weight)
+	dec.Decode(&op.StakingDays)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
@@ -4,7 +4,7 @@ type StakingObjects []StakingObject
 
 type StakingObject struct {
 	ID             ObjectID    `json:"id"`
-	Owner          ObjectID    `json:owner`
+	Owner          ObjectID    `json:"owner"`
 	TrustNode      ObjectID    `json:"trust_node"`
 	Amount         AssetAmount `json:"amount"`
 	CreateDateTime Time        `json:"create_date_time"`
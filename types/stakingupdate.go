@@ -40,3 +40,13 @@ func (op *StakingUpdateOperation) MarshalTransaction(encoder *transaction.Encode
 	enc.EncodeUVarint(0)
 	return enc.Err()
 }
+
+func (op *StakingUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Owner = decodeAccountID(dec)
+	op.TrustNode = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeWitness)
+	op.StakingId = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeStaking)
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
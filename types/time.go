@@ -33,3 +33,13 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 func (t Time) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeLittleEndianUInt32(uint32(t.Time.UTC().Unix()))
 }
+
+func (t *Time) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	seconds, err := decoder.DecodeLittleEndianUInt32()
+	if err != nil {
+		return err
+	}
+	parsed := time.Unix(int64(seconds), 0).UTC()
+	t.Time = &parsed
+	return nil
+}
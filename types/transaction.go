@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+	"encoding/hex"
 	"github.com/pkg/errors"
 	"gxclient-go/transaction"
 )
@@ -41,6 +43,61 @@ func (tx *Transaction) MarshalTransaction(encoder *transaction.Encoder) error {
 	return enc.Err()
 }
 
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller interface,
+// it reads the transaction without signatures.
+func (tx *Transaction) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+
+	dec.Decode(&tx.RefBlockNum)
+	dec.Decode(&tx.RefBlockPrefix)
+	dec.Decode(&tx.Expiration)
+	dec.Decode(&tx.Operations)
+
+	// Extensions are not supported yet.
+	decodeRawExtensions(dec)
+
+	return dec.Err()
+}
+
+// NewTransactionFromHex parses a serialized transaction, the signatures are read if the hex
+// was serialized as a signed transaction
+func NewTransactionFromHex(txHex string) (*Transaction, error) {
+	raw, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction hex")
+	}
+
+	r := bytes.NewReader(raw)
+	decoder := transaction.NewDecoder(r)
+
+	tx := &Transaction{}
+	if err := decoder.Decode(tx); err != nil {
+		return nil, err
+	}
+
+	tx.Signatures = []string{}
+	if r.Len() == 0 {
+		return tx, nil
+	}
+
+	n, err := decoder.DecodeLength()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signatures length")
+	}
+	for i := 0; i < n; i++ {
+		sig, err := decoder.DecodeBytes(65)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode signature")
+		}
+		tx.Signatures = append(tx.Signatures, hex.EncodeToString(sig))
+	}
+
+	if r.Len() != 0 {
+		return nil, errors.Errorf("%d unexpected bytes after the transaction", r.Len())
+	}
+	return tx, nil
+}
+
 // PushOperation can be used to add an operation into the transaction.
 func (tx *Transaction) PushOperation(op Operation) {
 	tx.Operations = append(tx.Operations, op)
@@ -50,3 +50,19 @@ func (op *TransferOperation) MarshalTransaction(encoder *transaction.Encoder) er
 	enc.EncodeUVarint(0)
 	return enc.Err()
 }
+
+func (op *TransferOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.From = decodeAccountID(dec)
+	op.To = decodeAccountID(dec)
+	dec.Decode(&op.Amount)
+
+	//Memo?
+	if dec.DecodeUVarint() == 1 {
+		op.Memo = &Memo{}
+		dec.Decode(op.Memo)
+	}
+	op.Extensions = decodeRawExtensions(dec)
+	return dec.Err()
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"gxclient-go/transaction"
+)
+
+// NewTrustNodePledgeWithdrawOperation returns a new instance of TrustNodePledgeWithdrawOperation
+func NewTrustNodePledgeWithdrawOperation(witnessAccount ObjectID, fee AssetAmount) *TrustNodePledgeWithdrawOperation {
+	op := &TrustNodePledgeWithdrawOperation{
+		Fee:            fee,
+		WitnessAccount: witnessAccount,
+	}
+	return op
+}
+
+// TrustNodePledgeWithdrawOperation withdraws the pledge of a trust node that is no longer active
+type TrustNodePledgeWithdrawOperation struct {
+	Fee            AssetAmount `json:"fee"`
+	WitnessAccount ObjectID    `json:"witness_account"`
+}
+
+func (op *TrustNodePledgeWithdrawOperation) Type() OpType { return TrustNodePledgeWithdrawOpType }
+
+func (op *TrustNodePledgeWithdrawOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.WitnessAccount)
+	return enc.Err()
+}
+
+func (op *TrustNodePledgeWithdrawOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.WitnessAccount = decodeAccountID(dec)
+	return dec.Err()
+}
@@ -15,6 +15,7 @@ var (
 	ErrPublicKeyChainPrefixMismatch = fmt.Errorf("PublicKey database prefix mismatch")
 	ErrAddressChainPrefixMismatch   = fmt.Errorf("Address database prefix mismatch")
 	ErrInvalidChecksum              = fmt.Errorf("invalid checksum")
+	ErrInvalidMemo                  = fmt.Errorf("invalid memo")
 	ErrNoSigningKeyFound            = fmt.Errorf("no signing key found")
 	ErrNoVerifyingKeyFound          = fmt.Errorf("no verifying key found")
 	ErrInvalidDigestLength          = fmt.Errorf("invalid digest length")
@@ -47,6 +48,22 @@ func (num UInt64) MarshalTransaction(enc *transaction.Encoder) error {
 	return enc.EncodeNumber(uint64(num))
 }
 
+func (num *UInt8) UnmarshalTransaction(dec *transaction.Decoder) error {
+	return dec.DecodeNumber((*uint8)(num))
+}
+
+func (num *UInt16) UnmarshalTransaction(dec *transaction.Decoder) error {
+	return dec.DecodeNumber((*uint16)(num))
+}
+
+func (num *UInt32) UnmarshalTransaction(dec *transaction.Decoder) error {
+	return dec.DecodeNumber((*uint32)(num))
+}
+
+func (num *UInt64) UnmarshalTransaction(dec *transaction.Decoder) error {
+	return dec.DecodeNumber((*uint64)(num))
+}
+
 type WorkerInitializerType UInt8
 
 const (
@@ -104,6 +121,12 @@ const (
 	ObjectTypeBalance
 )
 
+// GXChain specific object types of SpaceTypeProtocol
+const (
+	ObjectTypeLockBalance ObjectType = 17
+	ObjectTypeStaking     ObjectType = 27
+)
+
 // for SpaceTypeImplementation
 const (
 	ObjectTypeGlobalProperty ObjectType = iota + 1
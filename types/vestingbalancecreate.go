@@ -0,0 +1,159 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gxclient-go/transaction"
+)
+
+type LinearVestingPolicyInitializer struct {
+	BeginTimestamp         Time   `json:"begin_timestamp"`
+	VestingCliffSeconds    uint32 `json:"vesting_cliff_seconds"`
+	VestingDurationSeconds uint32 `json:"vesting_duration_seconds"`
+}
+
+func (p LinearVestingPolicyInitializer) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(p.BeginTimestamp)
+	enc.Encode(p.VestingCliffSeconds)
+	enc.Encode(p.VestingDurationSeconds)
+	return enc.Err()
+}
+
+func (p *LinearVestingPolicyInitializer) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&p.BeginTimestamp)
+	dec.Decode(&p.VestingCliffSeconds)
+	dec.Decode(&p.VestingDurationSeconds)
+	return dec.Err()
+}
+
+type CddVestingPolicyInitializer struct {
+	StartClaim     Time   `json:"start_claim"`
+	VestingSeconds uint32 `json:"vesting_seconds"`
+}
+
+func (p CddVestingPolicyInitializer) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(p.StartClaim)
+	enc.Encode(p.VestingSeconds)
+	return enc.Err()
+}
+
+func (p *CddVestingPolicyInitializer) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&p.StartClaim)
+	dec.Decode(&p.VestingSeconds)
+	return dec.Err()
+}
+
+// VestingPolicyInitializer is [policyType, policy] in JSON, Policy is a
+// *LinearVestingPolicyInitializer or a *CddVestingPolicyInitializer
+type VestingPolicyInitializer struct {
+	Type   VestingPolicyType
+	Policy interface{}
+}
+
+func (p VestingPolicyInitializer) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{p.Type, p.Policy})
+}
+
+func (p *VestingPolicyInitializer) UnmarshalJSON(data []byte) error {
+	raw := make([]json.RawMessage, 2)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal vesting policy: %v", string(data))
+	}
+	if len(raw) != 2 {
+		return errors.Errorf("invalid vesting policy: %v", string(data))
+	}
+	if err := json.Unmarshal(raw[0], &p.Type); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal vesting policy type: %v", string(raw[0]))
+	}
+
+	switch p.Type {
+	case VestingPolicyTypeLinear:
+		p.Policy = &LinearVestingPolicyInitializer{}
+	case VestingPolicyTypeCCD:
+		p.Policy = &CddVestingPolicyInitializer{}
+	default:
+		return errors.Errorf("unknown vesting policy type %d", p.Type)
+	}
+	return json.Unmarshal(raw[1], p.Policy)
+}
+
+func (p VestingPolicyInitializer) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(p.Type))
+	switch policy := p.Policy.(type) {
+	case *LinearVestingPolicyInitializer:
+		enc.Encode(policy)
+	case *CddVestingPolicyInitializer:
+		enc.Encode(policy)
+	default:
+		return errors.Errorf("unsupported vesting policy %T", p.Policy)
+	}
+	return enc.Err()
+}
+
+func (p *VestingPolicyInitializer) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	p.Type = VestingPolicyType(dec.DecodeUVarint())
+	if dec.Err() != nil {
+		return dec.Err()
+	}
+
+	switch p.Type {
+	case VestingPolicyTypeLinear:
+		p.Policy = &LinearVestingPolicyInitializer{}
+	case VestingPolicyTypeCCD:
+		p.Policy = &CddVestingPolicyInitializer{}
+	default:
+		return errors.Errorf("unknown vesting policy type %d", p.Type)
+	}
+	dec.Decode(p.Policy)
+	return dec.Err()
+}
+
+// NewVestingBalanceCreateOperation returns a new instance of VestingBalanceCreateOperation
+func NewVestingBalanceCreateOperation(creator, owner ObjectID, amount AssetAmount, policy VestingPolicyInitializer, fee AssetAmount) *VestingBalanceCreateOperation {
+	op := &VestingBalanceCreateOperation{
+		Fee:     fee,
+		Creator: creator,
+		Owner:   owner,
+		Amount:  amount,
+		Policy:  policy,
+	}
+	return op
+}
+
+// VestingBalanceCreateOperation
+type VestingBalanceCreateOperation struct {
+	Fee     AssetAmount              `json:"fee"`
+	Creator ObjectID                 `json:"creator"`
+	Owner   ObjectID                 `json:"owner"`
+	Amount  AssetAmount              `json:"amount"`
+	Policy  VestingPolicyInitializer `json:"policy"`
+}
+
+func (op *VestingBalanceCreateOperation) Type() OpType { return VestingBalanceCreateOpType }
+
+func (op *VestingBalanceCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Creator)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Amount)
+	enc.Encode(op.Policy)
+	return enc.Err()
+}
+
+func (op *VestingBalanceCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Creator = decodeAccountID(dec)
+	op.Owner = decodeAccountID(dec)
+	dec.Decode(&op.Amount)
+	dec.Decode(&op.Policy)
+	return dec.Err()
+}
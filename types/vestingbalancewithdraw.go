@@ -0,0 +1,45 @@
+package types
+
+import (
+	"gxclient-go/transaction"
+)
+
+// NewVestingBalanceWithdrawOperation returns a new instance of VestingBalanceWithdrawOperation
+func NewVestingBalanceWithdrawOperation(vestingBalance, owner ObjectID, amount, fee AssetAmount) *VestingBalanceWithdrawOperation {
+	op := &VestingBalanceWithdrawOperation{
+		Fee:            fee,
+		VestingBalance: vestingBalance,
+		Owner:          owner,
+		Amount:         amount,
+	}
+	return op
+}
+
+// VestingBalanceWithdrawOperation
+type VestingBalanceWithdrawOperation struct {
+	Fee            AssetAmount `json:"fee"`
+	VestingBalance ObjectID    `json:"vesting_balance"`
+	Owner          ObjectID    `json:"owner"`
+	Amount         AssetAmount `json:"amount"`
+}
+
+func (op *VestingBalanceWithdrawOperation) Type() OpType { return VestingBalanceWithdrawOpType }
+
+func (op *VestingBalanceWithdrawOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.VestingBalance)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Amount)
+	return enc.Err()
+}
+
+func (op *VestingBalanceWithdrawOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.VestingBalance = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeVestingBalance)
+	op.Owner = decodeAccountID(dec)
+	dec.Decode(&op.Amount)
+	return dec.Err()
+}
@@ -38,6 +38,24 @@ func (p Votes) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *Votes) UnmarshalTransaction(dec *transaction.Decoder) error {
+	n, err := dec.DecodeLength()
+	if err != nil {
+		return errors.Annotate(err, "decode length")
+	}
+
+	*p = Votes{}
+	for i := 0; i < n; i++ {
+		var vote VoteID
+		if err := dec.Decode(&vote); err != nil {
+			return errors.Annotate(err, "decode VoteID")
+		}
+		*p = append(*p, vote)
+	}
+
+	return nil
+}
+
 type VoteID struct {
 	typ      int
 	instance int
@@ -87,6 +105,17 @@ func (p VoteID) MarshalTransaction(enc *transaction.Encoder) error {
 	return nil
 }
 
+func (p *VoteID) UnmarshalTransaction(dec *transaction.Decoder) error {
+	bin, err := dec.DecodeLittleEndianUInt32()
+	if err != nil {
+		return errors.Annotate(err, "decode ID")
+	}
+
+	p.typ = int(bin & 0xff)
+	p.instance = int(bin >> 8)
+	return nil
+}
+
 func NewVoteID(id string) *VoteID {
 	v := VoteID{}
 	if err := v.UnmarshalJSON([]byte(id)); err != nil {
@@ -114,6 +143,29 @@ func NewVoteIDV2(id string) *VoteID {
 	return &v
 }
 
+// String returns the vote id as type:instance
+func (p VoteID) String() string {
+	return fmt.Sprintf("%d:%d", p.typ, p.instance)
+}
+
+// Sorted returns the votes sorted with VoteIDComparator without duplicates, the order of the chain
+func (p Votes) Sorted() Votes {
+	votes := make([]interface{}, len(p))
+	for idx, id := range p {
+		votes[idx] = id
+	}
+	sort.Sort(votes, VoteIDComparator)
+
+	sorted := Votes{}
+	for _, v := range votes {
+		if len(sorted) > 0 && VoteIDComparator(sorted[len(sorted)-1], v) == 0 {
+			continue
+		}
+		sorted = append(sorted, v.(VoteID))
+	}
+	return sorted
+}
+
 func VoteIDComparator(a, b interface{}) int {
 	aID := a.(VoteID)
 	bID := b.(VoteID)
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"gxclient-go/transaction"
+)
+
+// NewWitnessCreateOperation returns a new instance of WitnessCreateOperation
+func NewWitnessCreateOperation(witnessAccount ObjectID, url string, blockSigningKey PublicKey, fee AssetAmount) *WitnessCreateOperation {
+	op := &WitnessCreateOperation{
+		Fee:             fee,
+		WitnessAccount:  witnessAccount,
+		Url:             url,
+		BlockSigningKey: blockSigningKey,
+	}
+	return op
+}
+
+// WitnessCreateOperation
+type WitnessCreateOperation struct {
+	Fee             AssetAmount `json:"fee"`
+	WitnessAccount  ObjectID    `json:"witness_account"`
+	Url             string      `json:"url"`
+	BlockSigningKey PublicKey   `json:"block_signing_key"`
+}
+
+func (op *WitnessCreateOperation) Type() OpType { return WitnessCreateOpType }
+
+func (op *WitnessCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.WitnessAccount)
+	enc.Encode(op.Url)
+	enc.Encode(op.BlockSigningKey)
+	return enc.Err()
+}
+
+func (op *WitnessCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.WitnessAccount = decodeAccountID(dec)
+	dec.Decode(&op.Url)
+	dec.Decode(&op.BlockSigningKey)
+	return dec.Err()
+}
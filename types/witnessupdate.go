@@ -0,0 +1,73 @@
+package types
+
+import (
+	"gxclient-go/transaction"
+)
+
+// NewWitnessUpdateOperation returns a new instance of WitnessUpdateOperation, nil values are left unchanged
+func NewWitnessUpdateOperation(witness, witnessAccount ObjectID, newUrl *string, newSigningKey *PublicKey, fee AssetAmount) *WitnessUpdateOperation {
+	op := &WitnessUpdateOperation{
+		Fee:            fee,
+		Witness:        witness,
+		WitnessAccount: witnessAccount,
+		NewUrl:         newUrl,
+		NewSigningKey:  newSigningKey,
+	}
+	return op
+}
+
+// WitnessUpdateOperation
+type WitnessUpdateOperation struct {
+	Fee            AssetAmount `json:"fee"`
+	Witness        ObjectID    `json:"witness"`
+	WitnessAccount ObjectID    `json:"witness_account"`
+	NewUrl         *string     `json:"new_url,omitempty"`
+	NewSigningKey  *PublicKey  `json:"new_signing_key,omitempty"`
+}
+
+func (op *WitnessUpdateOperation) Type() OpType { return WitnessUpdateOpType }
+
+func (op *WitnessUpdateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(op.Type()))
+	enc.Encode(op.Fee)
+	enc.Encode(op.Witness)
+	enc.Encode(op.WitnessAccount)
+
+	//NewUrl?
+	if op.NewUrl != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(*op.NewUrl)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+
+	//NewSigningKey?
+	if op.NewSigningKey != nil {
+		enc.EncodeUVarint(1)
+		enc.Encode(op.NewSigningKey)
+	} else {
+		enc.EncodeUVarint(0)
+	}
+	return enc.Err()
+}
+
+func (op *WitnessUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Fee)
+	op.Witness = decodeObjectID(dec, SpaceTypeProtocol, ObjectTypeWitness)
+	op.WitnessAccount = decodeAccountID(dec)
+
+	//NewUrl?
+	if dec.DecodeUVarint() == 1 {
+		op.NewUrl = new(string)
+		dec.Decode(op.NewUrl)
+	}
+
+	//NewSigningKey?
+	if dec.DecodeUVarint() == 1 {
+		op.NewSigningKey = &PublicKey{}
+		dec.Decode(op.NewSigningKey)
+	}
+	return dec.Err()
+}
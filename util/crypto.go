@@ -6,6 +6,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/pquerna/ffjson/ffjson"
 	"golang.org/x/crypto/ripemd160"
+	"strings"
 )
 
 func Ripemd160(in []byte) ([]byte, error) {
@@ -68,6 +69,24 @@ func StringToName(str string) uint64 {
 	return name
 }
 
+func NameToString(name uint64) string {
+	const charmap = ".12345abcdefghijklmnopqrstuvwxyz"
+	str := make([]byte, 13)
+
+	tmp := name
+	for i := 0; i <= 12; i++ {
+		if i == 0 {
+			str[12-i] = charmap[tmp&0x0f]
+			tmp >>= 4
+		} else {
+			str[12-i] = charmap[tmp&0x1f]
+			tmp >>= 5
+		}
+	}
+
+	return strings.TrimRight(string(str), ".")
+}
+
 func ToBytes(in interface{}) []byte {
 	b, err := ffjson.Marshal(in)
 	if err != nil {
@@ -0,0 +1,147 @@
+package gxclient_go
+
+import (
+	"github.com/pkg/errors"
+	"gxclient-go/api/database"
+	"gxclient-go/types"
+)
+
+// vote types of the vote ids
+const (
+	voteTypeCommittee = 0
+	voteTypeWitness   = 1
+)
+
+// VoteFor adds the votes of the client account for the witnesses and committee members of accounts,
+// an account both witness and committee member gets both votes
+func (client *Client) VoteFor(accounts []string, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	return client.updateVotes(accounts, true, feeSymbol, broadcast)
+}
+
+// Unvote removes the votes of the client account for the witnesses and committee members of accounts
+func (client *Client) Unvote(accounts []string, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	return client.updateVotes(accounts, false, feeSymbol, broadcast)
+}
+
+// SetProxy makes account vote on behalf of the client account, an empty account makes the client account vote itself
+func (client *Client) SetProxy(account, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	proxyID := proxyToSelfAccount
+	if account != "" {
+		proxy, err := client.Database.GetAccount(account)
+		if err != nil {
+			return nil, err
+		}
+		proxyID = proxy.ID.String()
+	}
+
+	current, err := client.currentAccount()
+	if err != nil {
+		return nil, err
+	}
+	if current.Options.VotingAccount.String() == proxyID {
+		return nil, errors.Errorf("voting account is already %s", proxyID)
+	}
+
+	options := current.Options
+	options.VotingAccount = *types.NewGrapheneID(proxyID)
+	return client.updateOptions(options, feeSymbol, broadcast)
+}
+
+func (client *Client) updateVotes(accounts []string, add bool, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	if len(accounts) == 0 {
+		return nil, errors.New("no account to vote for")
+	}
+
+	var changes types.Votes
+	for _, account := range accounts {
+		votes, err := client.voteIDsOf(account)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, votes...)
+	}
+
+	current, err := client.currentAccount()
+	if err != nil {
+		return nil, err
+	}
+	voted := map[string]bool{}
+	for _, v := range current.Options.Votes {
+		voted[v.String()] = true
+	}
+
+	var votes types.Votes
+	if add {
+		votes = append(append(votes, current.Options.Votes...), changes...)
+	} else {
+		removed := map[string]bool{}
+		for _, v := range changes {
+			if !voted[v.String()] {
+				return nil, errors.Errorf("vote %s is not cast", v.String())
+			}
+			removed[v.String()] = true
+		}
+		for _, v := range current.Options.Votes {
+			if !removed[v.String()] {
+				votes = append(votes, v)
+			}
+		}
+	}
+	votes = votes.Sorted()
+
+	options := current.Options
+	options.Votes = votes
+	options.NumWitness, options.NumCommittee = 0, 0
+	for _, v := range votes {
+		switch v.GetType() {
+		case voteTypeWitness:
+			options.NumWitness++
+		case voteTypeCommittee:
+			options.NumCommittee++
+		}
+	}
+	return client.updateOptions(options, feeSymbol, broadcast)
+}
+
+// voteIDsOf returns the vote ids of the witness and the committee member of account
+func (client *Client) voteIDsOf(account string) (types.Votes, error) {
+	acc, err := client.Database.GetAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	var voteIDs []string
+	witness, err := client.Database.GetWitnessByAccount(acc.ID.String())
+	if err == nil {
+		voteIDs = append(voteIDs, witness.VoteId)
+	} else if errors.Cause(err) != database.ErrNotFound {
+		return nil, err
+	}
+
+	committee, err := client.Database.GetCommitteeMemberByAccount(acc.ID.String())
+	if err == nil {
+		voteIDs = append(voteIDs, committee.VoteId)
+	} else if errors.Cause(err) != database.ErrNotFound {
+		return nil, err
+	}
+
+	var votes types.Votes
+	for _, id := range voteIDs {
+		vote := types.NewVoteIDV2(id)
+		if vote == nil {
+			return nil, errors.Errorf("invalid vote id %s of account %s", id, account)
+		}
+		votes = append(votes, *vote)
+	}
+
+	if len(votes) == 0 {
+		return nil, errors.Errorf("account %s is neither witness nor committee member", account)
+	}
+	return votes, nil
+}
+
+func (client *Client) updateOptions(options types.AccountOptions, feeSymbol string, broadcast bool) (*types.TransactionResult, error) {
+	account := types.MustParseObjectID(client.account.ID.String())
+	op := types.NewAccountUpdateOperation(account, nil, nil, &options, types.AssetAmount{})
+	return client.signAndBroadcast(op, feeSymbol, broadcast)
+}
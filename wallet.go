@@ -0,0 +1,78 @@
+package gxclient_go
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"gxclient-go/types"
+	"gxclient-go/wallet"
+	"sort"
+	"strings"
+)
+
+// NewClientWithWallet creates a new RPC client for accountName signing with the keys of the wallet
+func NewClientWithWallet(w *wallet.Wallet, accountName, url string) (*Client, error) {
+	return NewClientWithWalletContext(context.Background(), w, accountName, url)
+}
+
+// NewClientWithWalletContext creates a new RPC client for accountName signing with the keys of the wallet,
+// ctx bounds the handshake with the node. The wallet must be unlocked: the memo key of the account is read
+// from the wallet when the client is created, the active key with the highest weight found in the wallet
+// is read on each signature so the wallet may be locked in between.
+func NewClientWithWalletContext(ctx context.Context, w *wallet.Wallet, accountName, url string) (*Client, error) {
+	if w.IsLocked() {
+		return nil, wallet.ErrLocked
+	}
+
+	cc, err := dial(url)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newClient(ctx, cc, nil, nil, accountName, strings.HasPrefix(url, "http"))
+	if err != nil {
+		cc.Close()
+		return nil, err
+	}
+
+	activeKey := walletActiveKey(w, &client.account.Active)
+	if activeKey == "" {
+		client.Close()
+		return nil, errors.Errorf("wallet has no active key of account %s", accountName)
+	}
+	if client.signer, err = w.Signer(activeKey); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	memoKey := client.account.Options.MemoKey
+	if !memoKey.IsNul() && w.HasKey(memoKey.String()) {
+		if client.memoPriKey, err = w.PrivateKey(memoKey.String()); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// walletActiveKey returns the key of authority with the highest weight that the wallet has, "" when it has none
+func walletActiveKey(w *wallet.Wallet, authority *types.Authority) string {
+	type candidate struct {
+		key    string
+		weight uint16
+	}
+	var candidates []candidate
+	for key, weight := range authority.KeyAuths {
+		if key != nil && !key.IsNul() && w.HasKey(key.String()) {
+			candidates = append(candidates, candidate{key: key.String(), weight: uint16(weight)})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].weight != candidates[j].weight {
+			return candidates[i].weight > candidates[j].weight
+		}
+		return candidates[i].key < candidates[j].key
+	})
+	return candidates[0].key
+}
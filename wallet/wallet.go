@@ -0,0 +1,439 @@
+// Package wallet keeps the private keys of several accounts in a password encrypted file.
+//
+// The keys are encrypted with AES-256-GCM under a key derived from the password with scrypt,
+// the account names and their public keys are kept in clear, authenticated with the keys,
+// so that they can be listed while the wallet is locked.
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	"gxclient-go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	version    = 1
+	kdfScrypt  = "scrypt"
+	cipherName = "aes-256-gcm"
+	keyLength  = 32
+	saltLength = 32
+)
+
+var (
+	// ErrLocked is returned when the keys are needed while the wallet is locked
+	ErrLocked = errors.New("wallet is locked")
+	// ErrWrongPassword is returned by Unlock when the password does not decrypt the keys
+	ErrWrongPassword = errors.New("wrong password or corrupted wallet")
+	// ErrKeyNotFound is returned when the wallet has no key for a public key
+	ErrKeyNotFound = errors.New("key not found in wallet")
+)
+
+// ScryptParams are the cost parameters of scrypt
+type ScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// DefaultScryptParams take about 100ms and 32MB to derive the key
+var DefaultScryptParams = ScryptParams{N: 1 << 15, R: 8, P: 1}
+
+type kdfParams struct {
+	ScryptParams
+	Salt string `json:"salt"`
+}
+
+// walletFile is the json layout of the wallet file
+type walletFile struct {
+	Version    int                 `json:"version"`
+	Cipher     string              `json:"cipher"`
+	KDF        string              `json:"kdf"`
+	KDFParams  kdfParams           `json:"kdf_params"`
+	Accounts   map[string][]string `json:"accounts"`
+	Nonce      string              `json:"nonce"`
+	Ciphertext string              `json:"ciphertext"`
+}
+
+// secrets is the encrypted content, the wif of each public key
+type secrets struct {
+	Keys map[string]string `json:"keys"`
+}
+
+// Wallet holds the keys of accounts, it is safe for concurrent use
+type Wallet struct {
+	mutex sync.Mutex
+
+	params     ScryptParams
+	salt       []byte
+	accounts   map[string][]string
+	nonce      []byte
+	ciphertext []byte
+
+	// set while unlocked
+	aesKey []byte
+	keys   map[string]*types.PrivateKey
+}
+
+// New creates an empty unlocked wallet encrypted with password
+func New(password string) (*Wallet, error) {
+	return NewWithScryptParams(password, DefaultScryptParams)
+}
+
+// NewWithScryptParams creates an empty unlocked wallet whose key is derived from password with params
+func NewWithScryptParams(password string, params ScryptParams) (*Wallet, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	w := &Wallet{
+		params:   params,
+		salt:     salt,
+		accounts: map[string][]string{},
+	}
+	aesKey, err := w.deriveKey(password)
+	if err != nil {
+		return nil, err
+	}
+	w.aesKey = aesKey
+	w.keys = map[string]*types.PrivateKey{}
+	if err := w.encrypt(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Load reads a locked wallet from the file at path
+func Load(path string) (*Wallet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read wallet")
+	}
+	return Parse(data)
+}
+
+// Parse reads a locked wallet from its json
+func Parse(data []byte) (*Wallet, error) {
+	var file walletFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal wallet")
+	}
+	if file.Version != version || file.Cipher != cipherName || file.KDF != kdfScrypt {
+		return nil, errors.Errorf("unsupported wallet version %d, cipher %s or kdf %s", file.Version, file.Cipher, file.KDF)
+	}
+
+	w := &Wallet{
+		params:   file.KDFParams.ScryptParams,
+		accounts: file.Accounts,
+	}
+	if w.accounts == nil {
+		w.accounts = map[string][]string{}
+	}
+	var err error
+	if w.salt, err = hex.DecodeString(file.KDFParams.Salt); err != nil {
+		return nil, errors.Wrap(err, "failed to decode salt")
+	}
+	if w.nonce, err = hex.DecodeString(file.Nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to decode nonce")
+	}
+	if w.ciphertext, err = hex.DecodeString(file.Ciphertext); err != nil {
+		return nil, errors.Wrap(err, "failed to decode ciphertext")
+	}
+	return w, nil
+}
+
+// MarshalJSON returns the wallet file, the keys stay encrypted
+func (w *Wallet) MarshalJSON() ([]byte, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return json.Marshal(walletFile{
+		Version:    version,
+		Cipher:     cipherName,
+		KDF:        kdfScrypt,
+		KDFParams:  kdfParams{ScryptParams: w.params, Salt: hex.EncodeToString(w.salt)},
+		Accounts:   w.accounts,
+		Nonce:      hex.EncodeToString(w.nonce),
+		Ciphertext: hex.EncodeToString(w.ciphertext),
+	})
+}
+
+// Save writes the wallet to the file at path, readable by the owner only
+func (w *Wallet) Save(path string) error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write a temporary file first so that a failed write does not lose the wallet
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to save wallet")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to save wallet")
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to save wallet")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to save wallet")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "failed to save wallet")
+}
+
+// IsLocked tells whether the keys are encrypted
+func (w *Wallet) IsLocked() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.aesKey == nil
+}
+
+// Lock forgets the decrypted keys
+func (w *Wallet) Lock() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for i := range w.aesKey {
+		w.aesKey[i] = 0
+	}
+	w.aesKey = nil
+	w.keys = nil
+}
+
+// Unlock decrypts the keys with password, it returns ErrWrongPassword when the password is wrong
+func (w *Wallet) Unlock(password string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	aesKey, err := w.deriveKey(password)
+	if err != nil {
+		return err
+	}
+	keys, err := w.decrypt(aesKey)
+	if err != nil {
+		return err
+	}
+	w.aesKey = aesKey
+	w.keys = keys
+	return nil
+}
+
+// AddKey adds the private key wif to the keys of account and returns its public key, the wallet must be unlocked
+func (w *Wallet) AddKey(account, wif string) (*types.PublicKey, error) {
+	key, err := types.NewPrivateKeyFromWif(wif)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid private key")
+	}
+	return w.addKey(account, key)
+}
+
+// AddBrainKey adds the key of brainKey, the one of keypair.GenerateKeyPair, to the keys of account
+// and returns its public key, the wallet must be unlocked
+func (w *Wallet) AddBrainKey(account, brainKey string) (*types.PublicKey, error) {
+	key, err := types.NewPrivateKeyFromBrainKey(brainKey, "0")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid brain key")
+	}
+	return w.addKey(account, key)
+}
+
+func (w *Wallet) addKey(account string, key *types.PrivateKey) (*types.PublicKey, error) {
+	if account == "" {
+		return nil, errors.New("account name is empty")
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.aesKey == nil {
+		return nil, ErrLocked
+	}
+
+	pub := key.PublicKey()
+	w.keys[pub.String()] = key
+	for _, p := range w.accounts[account] {
+		if p == pub.String() {
+			return pub, w.encrypt()
+		}
+	}
+	w.accounts[account] = append(w.accounts[account], pub.String())
+	return pub, w.encrypt()
+}
+
+// Accounts returns the sorted names of the accounts with keys in the wallet
+func (w *Wallet) Accounts() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	names := make([]string, 0, len(w.accounts))
+	for name := range w.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PublicKeys returns the public keys of account in the order they were added, the wallet may be locked
+func (w *Wallet) PublicKeys(account string) []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return append([]string{}, w.accounts[account]...)
+}
+
+// HasKey tells whether the wallet has the private key of publicKey, the wallet may be locked
+func (w *Wallet) HasKey(publicKey string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, pubs := range w.accounts {
+		for _, p := range pubs {
+			if p == publicKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PrivateKey returns the private key of publicKey, the wallet must be unlocked
+func (w *Wallet) PrivateKey(publicKey string) (*types.PrivateKey, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.aesKey == nil {
+		return nil, ErrLocked
+	}
+	key, ok := w.keys[publicKey]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+func (w *Wallet) deriveKey(password string) ([]byte, error) {
+	key, err := scrypt.Key([]byte(password), w.salt, w.params.N, w.params.R, w.params.P, keyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key")
+	}
+	return key, nil
+}
+
+// additionalData authenticates the accounts kept in clear
+func (w *Wallet) additionalData() ([]byte, error) {
+	return json.Marshal(w.accounts)
+}
+
+// encrypt encrypts the keys with a new nonce, it is called with the mutex held and the wallet unlocked
+func (w *Wallet) encrypt() error {
+	plain := secrets{Keys: map[string]string{}}
+	for pub, key := range w.keys {
+		plain.Keys[pub] = key.ToWIF()
+	}
+	data, err := json.Marshal(plain)
+	if err != nil {
+		return err
+	}
+	ad, err := w.additionalData()
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(w.aesKey)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+	w.nonce = nonce
+	w.ciphertext = gcm.Seal(nil, nonce, data, ad)
+	return nil
+}
+
+// decrypt decrypts the keys with aesKey, it is called with the mutex held
+func (w *Wallet) decrypt(aesKey []byte) (map[string]*types.PrivateKey, error) {
+	ad, err := w.additionalData()
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(w.nonce) != gcm.NonceSize() {
+		return nil, ErrWrongPassword
+	}
+	data, err := gcm.Open(nil, w.nonce, w.ciphertext, ad)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	var plain secrets
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal keys")
+	}
+	keys := map[string]*types.PrivateKey{}
+	for pub, wif := range plain.Keys {
+		key, err := types.NewPrivateKeyFromWif(wif)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid private key of %s", pub)
+		}
+		keys[pub] = key
+	}
+	return keys, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Signer returns a signer with the key of publicKey, the key is read from the wallet on each signature
+// so the wallet must be unlocked when signing
+func (w *Wallet) Signer(publicKey string) (types.Signer, error) {
+	pub, err := types.NewPublicKeyFromString(publicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key: %v", publicKey)
+	}
+	if !w.HasKey(publicKey) {
+		return nil, ErrKeyNotFound
+	}
+	return &walletSigner{wallet: w, publicKey: pub}, nil
+}
+
+type walletSigner struct {
+	wallet    *Wallet
+	publicKey *types.PublicKey
+}
+
+func (s *walletSigner) PublicKey() *types.PublicKey {
+	return s.publicKey
+}
+
+func (s *walletSigner) Sign(digest []byte) ([]byte, *types.PublicKey, error) {
+	key, err := s.wallet.PrivateKey(s.publicKey.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	return types.NewKeySigner(key).Sign(digest)
+}